@@ -2,13 +2,17 @@ package dependency
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
 	"github.com/bsm/redislock"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/fx"
 )
 
@@ -61,3 +65,53 @@ func InvokeRedis(rdb *redis.Client) error {
 func ProvideRedisLock(c *redis.Client) *redislock.Client {
 	return redislock.New(c)
 }
+
+func ProvideRedisHealthCheck(c config.Cfg, rdb *redis.Client) xhealth.Check {
+	return NewHealthCheck(c, "redis", dependencyCheckKinds, func(ctx context.Context) error {
+		return rdb.Ping(ctx).Err()
+	})
+}
+
+// InvokeRedisMetric records go-redis pool stats, the stats are observed on every metric collection.
+func InvokeRedisMetric(meter metric.Meter, rdb *redis.Client) error {
+	var (
+		attrPool   = attribute.String("pool.name", "redis")
+		attrIdle   = metric.WithAttributes(attrPool, attribute.String("state", "idle"))
+		attrUsed   = metric.WithAttributes(attrPool, attribute.String("state", "used"))
+		attrOnly   = metric.WithAttributes(attrPool)
+		errs       []error
+		newCounter = func(name, desc string) metric.Int64ObservableCounter {
+			c, err := meter.Int64ObservableCounter(name, metric.WithDescription(desc))
+			errs = append(errs, err)
+			return c
+		}
+
+		hits     = newCounter("redis.client.connections.hits", "Number of times free connection was found in the pool")
+		misses   = newCounter("redis.client.connections.misses", "Number of times free connection was not found in the pool")
+		timeouts = newCounter("redis.client.connections.timeouts", "Number of times a wait timeout occurred")
+		stale    = newCounter("redis.client.connections.stale", "Number of stale connections removed from the pool")
+	)
+
+	usage, err := meter.Int64ObservableGauge(
+		"redis.client.connections.usage",
+		metric.WithDescription("Number of connections that are currently in state described by the state attribute"),
+	)
+	errs = append(errs, err)
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stat := rdb.PoolStats()
+		o.ObserveInt64(usage, int64(stat.IdleConns), attrIdle)
+		o.ObserveInt64(usage, int64(stat.TotalConns-stat.IdleConns), attrUsed)
+		o.ObserveInt64(hits, int64(stat.Hits), attrOnly)
+		o.ObserveInt64(misses, int64(stat.Misses), attrOnly)
+		o.ObserveInt64(timeouts, int64(stat.Timeouts), attrOnly)
+		o.ObserveInt64(stale, int64(stat.StaleConns), attrOnly)
+		return nil
+	}, usage, hits, misses, timeouts, stale)
+
+	return err
+}
@@ -3,10 +3,13 @@ package dependency
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"slices"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/fx"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -18,7 +21,9 @@ import (
 
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/gen/gorm/query"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtenant"
 )
 
 func ProvideGormQuery(db *gorm.DB) *query.Query {
@@ -64,7 +69,13 @@ func ProvidePostgres(c config.Cfg, s config.Server, d *xlog.DebugLogger, lc fx.L
 
 	// Exclude migration-command and gorm-generator-command
 	if !slices.Contains([]string{"migration-command", "gorm-generator-command"}, s.Name) {
-		poolCfg.ConnConfig.Tracer = &xlog.PgxLogger{Log: xlog.NewLogger(d.Logger)}
+		poolCfg.ConnConfig.Tracer = &xlog.PgxLogger{Log: xlog.NewLogger(d.Logger), Redactor: d.Redactor}
+
+		// Bind 'app.tenant_id' on every acquired connection for postgres row level security
+		if c.Tenant.Enabled && c.Tenant.DB.RowLevelSecurity {
+			poolCfg.BeforeAcquire = xtenant.PgxBeforeAcquire
+			poolCfg.AfterRelease = xtenant.PgxAfterRelease
+		}
 	}
 
 	db, err := pgxpool.NewWithConfig(ctx, poolCfg)
@@ -150,3 +161,82 @@ func ProvideGormPostgres(p ProvideGormPostgresParamFx) (*gorm.DB, error) {
 
 	return db, nil
 }
+
+func ProvidePostgresHealthCheck(c config.Cfg, db *pgxpool.Pool) xhealth.Check {
+	return NewHealthCheck(c, "postgres", dependencyCheckKinds, db.Ping)
+}
+
+// ProvidePostgresPoolHealthCheck fails when acquired connections ratio reaches 'health.pool.saturation'.
+func ProvidePostgresPoolHealthCheck(c config.Cfg, db *pgxpool.Pool) xhealth.Check {
+	threshold := c.Health.PoolSaturation
+	if threshold <= 0 {
+		threshold = 0.9
+	}
+
+	return NewHealthCheck(c, "postgres.pool", []xhealth.Kind{xhealth.KindReadiness}, func(ctx context.Context) error {
+		stat := db.Stat()
+		if stat.MaxConns() == 0 {
+			return nil
+		}
+
+		ratio := float64(stat.AcquiredConns()) / float64(stat.MaxConns())
+		if ratio >= threshold {
+			return fmt.Errorf("postgres pool is saturated: %d of %d connections are acquired", stat.AcquiredConns(), stat.MaxConns())
+		}
+		return nil
+	})
+}
+
+// InvokePostgresMetric records pgx pool stats, the stats are observed on every metric collection.
+func InvokePostgresMetric(meter metric.Meter, db *pgxpool.Pool) error {
+	var (
+		attrPool = attribute.String("pool.name", "postgres")
+		attrIdle = metric.WithAttributes(attrPool, attribute.String("state", "idle"))
+		attrUsed = metric.WithAttributes(attrPool, attribute.String("state", "used"))
+		attrOnly = metric.WithAttributes(attrPool)
+		errs     []error
+		newGauge = func(name, desc string) metric.Int64ObservableGauge {
+			g, err := meter.Int64ObservableGauge(name, metric.WithDescription(desc))
+			errs = append(errs, err)
+			return g
+		}
+		newCounter = func(name, desc string) metric.Int64ObservableCounter {
+			c, err := meter.Int64ObservableCounter(name, metric.WithDescription(desc))
+			errs = append(errs, err)
+			return c
+		}
+
+		usage        = newGauge("db.client.connections.usage", "Number of connections that are currently in state described by the state attribute")
+		maxConns     = newGauge("db.client.connections.max", "Maximum number of connections allowed by the pool")
+		constructing = newGauge("db.client.connections.constructing", "Number of connections which are being established")
+		acquire      = newCounter("db.client.connections.acquire.count", "Cumulative count of successful acquires from the pool")
+		emptyAcquire = newCounter("db.client.connections.acquire.empty", "Cumulative count of acquires which waited for a connection because the pool was empty")
+		cancelled    = newCounter("db.client.connections.acquire.canceled", "Cumulative count of acquires which were canceled by context")
+	)
+
+	waitTime, err := meter.Float64ObservableCounter(
+		"db.client.connections.acquire.duration",
+		metric.WithDescription("Total duration of every successful acquire from the pool"),
+		metric.WithUnit("s"),
+	)
+	errs = append(errs, err)
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stat := db.Stat()
+		o.ObserveInt64(usage, int64(stat.IdleConns()), attrIdle)
+		o.ObserveInt64(usage, int64(stat.AcquiredConns()), attrUsed)
+		o.ObserveInt64(maxConns, int64(stat.MaxConns()), attrOnly)
+		o.ObserveInt64(constructing, int64(stat.ConstructingConns()), attrOnly)
+		o.ObserveInt64(acquire, stat.AcquireCount(), attrOnly)
+		o.ObserveInt64(emptyAcquire, stat.EmptyAcquireCount(), attrOnly)
+		o.ObserveInt64(cancelled, stat.CanceledAcquireCount(), attrOnly)
+		o.ObserveFloat64(waitTime, stat.AcquireDuration().Seconds(), attrOnly)
+		return nil
+	}, usage, maxConns, constructing, acquire, emptyAcquire, cancelled, waitTime)
+
+	return err
+}
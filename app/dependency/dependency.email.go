@@ -2,6 +2,7 @@ package dependency
 
 import (
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xmail"
 )
 
@@ -20,3 +21,7 @@ func ProvideXGmail(c config.Cfg) *xmail.XGmail {
 
 	return xmail.NewXGmail(xcfg)
 }
+
+func ProvideXGmailHealthCheck(c config.Cfg, m *xmail.XGmail) xhealth.Check {
+	return NewHealthCheck(c, "smtp", []xhealth.Kind{xhealth.KindReadiness}, m.Ping)
+}
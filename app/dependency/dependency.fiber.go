@@ -3,14 +3,10 @@ package dependency
 import (
 	"context"
 	"fmt"
-	"sort"
 	"strconv"
 	"time"
 
-	"github.com/danielgtaylor/huma/v2"
-	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
 	"github.com/gofiber/fiber/v2"
-	"go.uber.org/fx"
 
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
@@ -19,55 +15,25 @@ import (
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/infra/http/middleware"
 )
 
-func ProvideHumaFiber(a *fiber.App, c huma.Config) huma.API {
-	var (
-		api = humafiber.New(a, c)
-	)
-	return api
-}
-
-type ProvideFiberFxParam struct {
-	fx.In
-
-	Cfg fiber.Config
-	Mdl []xhuma.GlobalMiddleware `group:"global:http:middleware"`
-}
-
-func ProvideFiber(p ProvideFiberFxParam) *fiber.App {
-	var (
-		app = fiber.New(p.Cfg)
-	)
-
-	sort.Slice(p.Mdl, func(i, j int) bool {
-		var (
-			a = p.Mdl[i].Name()
-			b = p.Mdl[j].Name()
-		)
-
-		var (
-			aa = middleware.GlobalOrders[a]
-			bb = middleware.GlobalOrders[b]
-		)
-
-		return aa < bb
-	})
+// NewFiber registers the global middleware chain which is resolved by 'middleware.GlobalChain', every
+// middleware handler is built once by its provider, so it is not created per request.
+func NewFiber(cfg fiber.Config, mdlCfg config.Middleware, chain []xhuma.GlobalMiddleware) *fiber.App {
+	app := fiber.New(cfg)
 
-	for _, mdl := range p.Mdl {
-		mdl.App(app)
-		app.Use(mdl.Serve)
+	for _, m := range chain {
+		m.App(app)
+		app.Use(middleware.GlobalHandler(mdlCfg, m))
 	}
 
 	return app
 }
 
-func ProvideFiberConfig(c config.Cfg, l *xlog.DebugLogger) fiber.Config {
+func NewFiberConfig(svr config.Server, log xlog.Logger) fiber.Config {
 	var (
 		ctx = context.Background()
-		log = xlog.NewLogger(l.Logger)
 	)
 
 	var (
-		svr        = c.Server["http"]
 		add        = svr.Additional
 		prefork, _ = add["prefork"]
 		fbr        = fiber.Config{
@@ -86,6 +52,19 @@ func ProvideFiberConfig(c config.Cfg, l *xlog.DebugLogger) fiber.Config {
 	if dur, ok := parseDurrationConfig(ctx, log, add, "read.timeout"); ok {
 		fbr.ReadTimeout = dur // default is unlimited
 	}
+	if v, ok := add["body.limit"]; ok {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			fbr.BodyLimit = n // default is 4MB
+		} else {
+			log.Error(ctx, "failed to parse http additional config: 'body.limit'", "err", fmt.Sprintf("%+v", err))
+		}
+	}
+
+	// request body which is larger than body limit is streamed to the handler instead of rejected
+	fbr.StreamRequestBody = add["stream.request.body"] == "true"
+
+	// pre-parsed multipart form consumes the stream, so it is parsed lazily by the handler instead
+	fbr.DisablePreParseMultipartForm = fbr.StreamRequestBody
 
 	return fbr
 }
@@ -0,0 +1,9 @@
+package dependency
+
+import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgraceful"
+)
+
+func ProvideGracefulTracker() *xgraceful.Tracker {
+	return xgraceful.NewTracker()
+}
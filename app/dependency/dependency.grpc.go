@@ -0,0 +1,175 @@
+package dependency
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/infra/grpc/interceptor"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgrpc"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+const (
+	ServerProtocolGRPC = "grpc"
+)
+
+// GRPCServer is a grpc listener which is declared in 'server.<key>' config with 'protocol: grpc'.
+type GRPCServer struct {
+	Key    string
+	Config config.Server
+	Server *grpc.Server
+
+	// TLS is nil when 'server.<key>.tls' is disabled.
+	TLS *tls.Config
+}
+
+type GRPCServers []*GRPCServer
+
+type ProvideGRPCServersParam struct {
+	fx.In
+
+	Lc           fx.Lifecycle
+	Cfg          config.Cfg
+	Log          *xlog.DebugLogger
+	Health       *xhealth.Registry
+	Interceptors []xgrpc.Interceptor     `group:"global:grpc:interceptor"`
+	Services     []xgrpc.ServiceRegister `group:"global:grpc:service"`
+}
+
+func ProvideGRPCServers(p ProvideGRPCServersParam) (GRPCServers, error) {
+	var (
+		log     = xlog.NewLogger(p.Log.Logger)
+		keys    = make([]string, 0)
+		servers = make(GRPCServers, 0)
+	)
+
+	for key, svr := range p.Cfg.Server {
+		if !svr.Disabled && svr.Protocol == ServerProtocolGRPC {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var (
+			svr    = p.Cfg.Server[key]
+			add    = svr.Additional
+			groups = svr.HandlerGroups
+			opts   = NewGRPCInterceptors(p.Interceptors, svr.Middlewares)
+		)
+
+		tlsCfg, err := NewServerTLSConfig(p.Lc, log, key, svr.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup tls for server '%s': %w", key, err)
+		}
+		if tlsCfg != nil {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+		}
+
+		if v, ok := add["max.recv.msg.size"]; ok {
+			if n, err := strconv.Atoi(v); err == nil && n > 0 {
+				opts = append(opts, grpc.MaxRecvMsgSize(n)) // default is 4MB
+			} else {
+				log.Error(context.Background(), "failed to parse grpc additional config: 'max.recv.msg.size'", "server", key, "err", fmt.Sprintf("%+v", err))
+			}
+		}
+
+		var (
+			server   = grpc.NewServer(opts...)
+			watch, _ = strconv.Atoi(add["health.watch.interval"])
+		)
+
+		grpc_health_v1.RegisterHealthServer(server, xgrpc.NewHealthServer(p.Health, time.Duration(watch)*time.Second))
+		if add["reflection"] == "true" {
+			reflection.Register(server)
+		}
+
+		if len(groups) == 0 {
+			groups = []string{xhuma.DefaultHandlerGroup}
+		}
+		for _, s := range p.Services {
+			if slices.Contains(groups, xhuma.HandlerGroupOf(s)) {
+				s.Register(server)
+			}
+		}
+
+		servers = append(servers, &GRPCServer{
+			Key:    key,
+			Config: svr,
+			Server: server,
+			TLS:    tlsCfg,
+		})
+	}
+
+	return servers, nil
+}
+
+// NewGRPCInterceptors chains global interceptors by 'interceptor.GlobalOrders',
+// when 'names' is not empty only the listed interceptors are used.
+func NewGRPCInterceptors(interceptors []xgrpc.Interceptor, names []string) []grpc.ServerOption {
+	use := make([]xgrpc.Interceptor, 0, len(interceptors))
+	for _, i := range interceptors {
+		if len(names) == 0 || slices.Contains(names, i.Name()) {
+			use = append(use, i)
+		}
+	}
+
+	sort.Slice(use, func(i, j int) bool {
+		return interceptor.GlobalOrders[use[i].Name()] < interceptor.GlobalOrders[use[j].Name()]
+	})
+
+	var (
+		unary  = make([]grpc.UnaryServerInterceptor, len(use))
+		stream = make([]grpc.StreamServerInterceptor, len(use))
+	)
+
+	for i, u := range use {
+		unary[i], stream[i] = u.Unary, u.Stream
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+func (s *GRPCServer) Listen() (net.Listener, error) {
+	return net.Listen("tcp", s.Config.Address)
+}
+
+// Serve serves the server on the given listener, it blocks until the server is stopped.
+func (s *GRPCServer) Serve(ln net.Listener) error {
+	return s.Server.Serve(ln)
+}
+
+// Shutdown waits for pending calls and streams, they are forcibly closed once the context is done.
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.Server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.Server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,66 @@
+package dependency
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgraceful"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
+)
+
+var (
+	dependencyCheckKinds = []xhealth.Kind{xhealth.KindReadiness, xhealth.KindStartup}
+)
+
+// NewHealthCheck applies 'health.checks.<name>' config into the check,
+// disabled check has nil function, so it is skipped by the registry.
+func NewHealthCheck(c config.Cfg, name string, kinds []xhealth.Kind, fn func(ctx context.Context) error) xhealth.Check {
+	cfg := c.Health.Checks[name]
+	if cfg.Disabled {
+		fn = nil
+	}
+
+	return xhealth.Check{
+		Name:     name,
+		Kinds:    kinds,
+		Critical: !cfg.Optional,
+		Timeout:  time.Duration(cfg.Timeout) * time.Second,
+		CacheTTL: time.Duration(cfg.CacheTTL) * time.Second,
+		Fn:       fn,
+	}
+}
+
+type ProvideHealthRegistryParam struct {
+	fx.In
+
+	Cfg     config.Cfg
+	Tracker *xgraceful.Tracker
+	Checks  []xhealth.Check `group:"global:health:check"`
+}
+
+func ProvideHealthRegistry(p ProvideHealthRegistryParam) *xhealth.Registry {
+	registry := xhealth.NewRegistry(
+		xhealth.Options{
+			Timeout:  time.Duration(p.Cfg.Health.Timeout) * time.Second,
+			CacheTTL: time.Duration(p.Cfg.Health.CacheTTL) * time.Second,
+		},
+		p.Checks...,
+	)
+	registry.SetDrainingFn(p.Tracker.IsDraining)
+
+	return registry
+}
+
+// InvokeHealthStartup must be invoked after every startup module,
+// so startup probe is only passing once migrations, seeders and servers are started.
+func InvokeHealthStartup(lc fx.Lifecycle, registry *xhealth.Registry) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			registry.MarkStarted()
+			return nil
+		},
+	})
+}
@@ -1,20 +1,30 @@
 package dependency
 
 import (
-	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"go.opentelemetry.io/otel/propagation"
 	"resty.dev/v3"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtracer"
 )
 
 func ProvideHttpClient(debuglog *xlog.DebugLogger) *resty.Client {
 	var (
 		xl = xlog.NewLogger(debuglog.Logger)
-		l  = xlog.NewRestyV3Logger(xl)
-		c  = resty.New().EnableTrace().EnableDebug()
+		l  = xlog.NewRestyV3Logger(xl, debuglog.Redactor)
+		c  = resty.New().EnableTrace()
 	)
 
 	return c.
 		SetLogger(l).
 		SetDebugLogFormatter(
 			resty.DebugLogJSONFormatter,
-		)
+		).
+		AddRequestMiddleware(PropagateRequestTrace)
+}
+
+// PropagateRequestTrace sends 'traceparent' and 'X-Request-ID' of the request context, so the called service continues the trace.
+func PropagateRequestTrace(_ *resty.Client, r *resty.Request) error {
+	xtracer.Inject(r.Context(), propagation.HeaderCarrier(r.Header))
+	return nil
 }
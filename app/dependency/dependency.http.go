@@ -2,22 +2,172 @@ package dependency
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
+	"google.golang.org/grpc"
 
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/infra/http/middleware"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgraceful"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpush"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtracer"
 )
 
+// ProvideHTTPServerName provides the server which names this process, i.e: otel service name, logger app name,
+// redis client name and postgres application name. It is the first enabled server by key order which declares
+// 'name', so renaming or disabling a server key does not leave the process unnamed, otherwise it is 'app.project'.
 func ProvideHTTPServerName(c config.Cfg) config.Server {
-	return c.Server["http"]
+	keys := make([]string, 0, len(c.Server))
+	for key := range c.Server {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if svr := c.Server[key]; !svr.Disabled && svr.Name != "" {
+			return svr
+		}
+	}
+
+	return config.Server{Name: c.App.Project}
+}
+
+// HTTPServer is a single listener which is declared in 'server.<key>' config,
+// every listener has its own fiber app, middleware chain, huma api and openapi document.
+type HTTPServer struct {
+	Key    string
+	Config config.Server
+	App    *fiber.App
+	API    huma.API
+
+	// Versions is the api of every 'api.versions' config, each of them has its own openapi document.
+	Versions map[string]huma.API
+
+	// TLS is nil when 'server.<key>.tls' is disabled.
+	TLS *tls.Config
+
+	// Std is only set when 'server.<key>.http2' is enabled.
+	Std *http.Server
+}
+
+type HTTPServers []*HTTPServer
+
+// APIOf returns the api of the handler version, empty version is the server api.
+func (s *HTTPServer) APIOf(version string) (huma.API, error) {
+	if version == "" {
+		return s.API, nil
+	}
+
+	api, ok := s.Versions[version]
+	if !ok {
+		return nil, fmt.Errorf("api version '%s' is not declared in 'api.versions' config", version)
+	}
+	return api, nil
+}
+
+// RouterOf returns the router under 'api.versions' prefix of the handler version, empty version is the server app.
+func (s *HTTPServer) RouterOf(api config.API, version string) (fiber.Router, error) {
+	if version == "" {
+		return s.App, nil
+	}
+
+	v, ok := api.Versions[version]
+	if !ok {
+		return nil, fmt.Errorf("api version '%s' is not declared in 'api.versions' config", version)
+	}
+	return s.App.Group(v.Prefix), nil
+}
+
+type ProvideHTTPServersParam struct {
+	fx.In
+
+	Lc       fx.Lifecycle
+	Cfg      config.Cfg
+	Log      *xlog.DebugLogger
+	Prom     *xtracer.Prometheus
+	Mdl      []xhuma.GlobalMiddleware `group:"global:http:middleware"`
+	Registry *middleware.OperationRegistry
+}
+
+func ProvideHTTPServers(p ProvideHTTPServersParam) (HTTPServers, error) {
+	var (
+		log     = xlog.NewLogger(p.Log.Logger)
+		keys    = make([]string, 0, len(p.Cfg.Server))
+		servers = make(HTTPServers, 0, len(p.Cfg.Server))
+	)
+
+	for key, svr := range p.Cfg.Server {
+		if !svr.Disabled && svr.Protocol != ServerProtocolGRPC {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	if len(keys) == 0 {
+		return nil, errors.New("no enabled server is declared in 'server' config")
+	}
+
+	for _, key := range keys {
+		var (
+			svr         = p.Cfg.Server[key]
+			chain       = middleware.GlobalChain(p.Cfg.Middleware, p.Mdl, svr.Middlewares)
+			withMonitor = slices.ContainsFunc(chain, func(m xhuma.GlobalMiddleware) bool { return m.Name() == "monitor" })
+			app         = NewFiber(NewFiberConfig(svr, log), p.Cfg.Middleware, chain)
+			api         = humafiber.New(app, NewHumaConfig(svr, withMonitor))
+		)
+
+		// metrics of every server are exposed only on the server which declares it, i.e: private debug server
+		if svr.Additional["metrics.endpoint"] == "true" && p.Prom.Enabled() {
+			app.Get("/metrics", adaptor.HTTPHandler(p.Prom.Handler()))
+		}
+
+		// unversioned operation may still declare its own deprecation
+		base := huma.NewGroup(api)
+		base.UseModifier(xhuma.VersionModifier("", nil))
+		p.Registry.Use(base)
+
+		versions, err := NewVersionAPIs(app, svr, p.Cfg.API, p.Registry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup api versions for server '%s': %w", key, err)
+		}
+
+		tlsCfg, err := NewServerTLSConfig(p.Lc, log, key, svr.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup tls for server '%s': %w", key, err)
+		}
+
+		server := &HTTPServer{
+			Key:      key,
+			Config:   svr,
+			App:      app,
+			API:      base,
+			Versions: versions,
+			TLS:      tlsCfg,
+		}
+
+		if svr.HTTP2.Enabled {
+			server.Std = NewServerStd(app, tlsCfg)
+		}
+
+		servers = append(servers, server)
+	}
+
+	return servers, nil
 }
 
 type InvokeHTTPServerParam struct {
@@ -26,42 +176,145 @@ type InvokeHTTPServerParam struct {
 	Lifecycle fx.Lifecycle
 
 	Cfg    config.Cfg
-	Server config.Server
 	Tracer trace.Tracer
 
-	HumaAPI  huma.API
-	FiberApp *fiber.App
-	Log      *xlog.DebugLogger
+	Servers     HTTPServers
+	GRPCServers GRPCServers
+	Log         *xlog.DebugLogger
+	Tracker     *xgraceful.Tracker
 
-	Handlers []xhuma.HandlerRegister `group:"global:http:handler"`
-}
+	Registry *middleware.OperationRegistry
 
-func InvokeHTTPServer(p InvokeHTTPServerParam) {
-	var (
-		cfg    = p.Server
-		logger = xlog.NewLogger(p.Log.Logger)
-	)
+	Handlers   []xhuma.HandlerRegister   `group:"global:http:handler"`
+	WebSockets []xpush.WebSocketRegister `group:"global:http:websocket"`
+}
 
-	for _, h := range p.Handlers {
-		h.Register(p.HumaAPI)
-	}
+// InvokeHTTPServer registers handlers and lifecycle hooks, fx runs OnStop hooks in reverse order,
+// so the shutdown sequence is:
+//
+//  1. flip readiness into failing and wait for 'shutdown.drain.period'.
+//  2. stop accepting connections on every http and grpc server.
+//  3. wait for in-flight requests and background goroutines (i.e: async incoming log).
+//  4. close redis and postgres, their hooks are registered before http servers.
+func InvokeHTTPServer(p InvokeHTTPServerParam) error {
+	logger := xlog.NewLogger(p.Log.Logger)
 
+	// # 3. wait in-flight requests and background goroutines
 	p.Lifecycle.Append(fx.Hook{
-		OnStart: func(ctx context.Context) error {
-			go func() {
-				logger.Info(ctx, "http server started", "address", cfg.Address)
-				if err := p.FiberApp.Listen(cfg.Address); err != nil && !errors.Is(err, net.ErrClosed) {
-					logger.Info(ctx, "failed to start http server", "err", fmt.Sprintf("%+v", err))
-				}
-			}()
+		OnStop: func(ctx context.Context) error {
+			logger.Info(ctx, "waiting for in-flight requests and background tasks", "inFlight", p.Tracker.InFlight())
+			if err := p.Tracker.Wait(ctx); err != nil {
+				return fmt.Errorf("failed to wait in-flight requests and background tasks: %w", err)
+			}
 			return nil
 		},
+	})
+
+	// # 2. stop accepting connections
+	for _, svr := range p.Servers {
+		var (
+			server = svr
+			cfg    = svr.Config
+			groups = cfg.HandlerGroups
+		)
+
+		if len(groups) == 0 {
+			groups = []string{xhuma.DefaultHandlerGroup}
+		}
+
+		for _, h := range p.Handlers {
+			if !slices.Contains(groups, xhuma.HandlerGroupOf(h)) {
+				continue
+			}
+
+			api, err := server.APIOf(xhuma.HandlerVersionOf(h))
+			if err != nil {
+				return fmt.Errorf("failed to register handler %T into server '%s': %w", h, server.Key, err)
+			}
+			h.Register(api)
+		}
+
+		for _, ws := range p.WebSockets {
+			if !slices.Contains(groups, xhuma.HandlerGroupOf(ws)) {
+				continue
+			}
+
+			router, err := server.RouterOf(p.Cfg.API, xhuma.HandlerVersionOf(ws))
+			if err != nil {
+				return fmt.Errorf("failed to register websocket %T into server '%s': %w", ws, server.Key, err)
+			}
+			ws.Register(router)
+		}
+
+		p.Lifecycle.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				ln, err := server.Listen()
+				if err != nil {
+					return fmt.Errorf("failed to listen http server '%s': %w", server.Key, err)
+				}
+
+				go func() {
+					logger.Info(ctx, "http server started", "server", server.Key, "address", cfg.Address, "tls", server.TLS != nil, "http2", server.Std != nil)
+					if err := server.Serve(ln); err != nil && !errors.Is(err, net.ErrClosed) {
+						logger.Error(ctx, "failed to start http server", "server", server.Key, "err", fmt.Sprintf("%+v", err))
+					}
+				}()
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				defer logger.Info(ctx, "http server stopped", "server", server.Key, "address", cfg.Address)
+				if err := server.Shutdown(ctx); err != nil && !errors.Is(err, net.ErrClosed) {
+					return err
+				}
+				return nil
+			},
+		})
+	}
+
+	if err := p.Registry.Err(); err != nil {
+		return fmt.Errorf("failed to apply operation middlewares: %w", err)
+	}
+
+	for _, svr := range p.GRPCServers {
+		server := svr
+
+		p.Lifecycle.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				ln, err := server.Listen()
+				if err != nil {
+					return fmt.Errorf("failed to listen grpc server '%s': %w", server.Key, err)
+				}
+
+				go func() {
+					logger.Info(ctx, "grpc server started", "server", server.Key, "address", server.Config.Address, "tls", server.TLS != nil)
+					if err := server.Serve(ln); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+						logger.Error(ctx, "failed to start grpc server", "server", server.Key, "err", fmt.Sprintf("%+v", err))
+					}
+				}()
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				defer logger.Info(ctx, "grpc server stopped", "server", server.Key, "address", server.Config.Address)
+				return server.Shutdown(ctx)
+			},
+		})
+	}
+
+	// # 1. readiness drain
+	p.Lifecycle.Append(fx.Hook{
 		OnStop: func(ctx context.Context) error {
-			defer logger.Info(ctx, "http server stopped", "address", cfg.Address)
-			if err := p.FiberApp.ShutdownWithContext(ctx); err != nil && !errors.Is(err, net.ErrClosed) {
-				return err
+			period := time.Duration(p.Cfg.Shutdown.DrainPeriod) * time.Second
+
+			p.Tracker.Drain()
+			logger.Info(ctx, "readiness is failing, draining traffic", "drainPeriod", period.String())
+
+			select {
+			case <-time.After(period):
+			case <-ctx.Done():
 			}
 			return nil
 		},
 	})
+
+	return nil
 }
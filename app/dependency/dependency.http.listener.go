@@ -0,0 +1,157 @@
+package dependency
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtls"
+)
+
+// NewServerTLSConfig builds tls config of the server, its certificate and client ca files are watched
+// from the start until the stop of the application.
+func NewServerTLSConfig(lc fx.Lifecycle, log xlog.Logger, key string, cfg config.ServerTLS) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	tlsCfg, reloader, err := xtls.NewConfig(xtls.Config{
+		CertFile:       cfg.CertFile,
+		KeyFile:        cfg.KeyFile,
+		ClientCAFile:   cfg.ClientCAFile,
+		ClientAuth:     cfg.ClientAuth,
+		MinVersion:     cfg.MinVersion,
+		CipherSuites:   cfg.CipherSuites,
+		ReloadInterval: time.Duration(cfg.ReloadInterval) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			reloader.Start(func(err error) {
+				log.Error(context.Background(), "failed to reload tls files, previous certificate is kept", "server", key, "err", fmt.Sprintf("%+v", err))
+			})
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			reloader.Stop()
+			return nil
+		},
+	})
+
+	return tlsCfg, nil
+}
+
+// NewServerStd creates net/http server for HTTP/2, because it is not supported by fasthttp,
+// so fiber app is served through fiber adaptor. h2 is negotiated by ALPN when TLS is enabled,
+// otherwise h2c (HTTP/2 cleartext) is used.
+//
+// The adaptor buffers the whole request and response, so 'body.limit' is enforced on every request
+// regardless of 'stream.request.body', and streaming response (i.e: SSE, WebSocket) is not supported.
+// Keep HTTP/2 disabled on the server which serves streaming, TLS is then served by fasthttp itself.
+func NewServerStd(app *fiber.App, tlsCfg *tls.Config) *http.Server {
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+
+	if tlsCfg != nil {
+		protocols.SetHTTP2(true)
+	} else {
+		protocols.SetUnencryptedHTTP2(true)
+	}
+
+	limit := app.Config().BodyLimit
+	if limit <= 0 {
+		limit = fiber.DefaultBodyLimit
+	}
+
+	return &http.Server{
+		Handler:      bodyLimitHandler(int64(limit), adaptor.FiberApp(app)),
+		TLSConfig:    tlsCfg,
+		Protocols:    &protocols,
+		IdleTimeout:  app.Config().IdleTimeout,
+		ReadTimeout:  app.Config().ReadTimeout,
+		WriteTimeout: app.Config().WriteTimeout,
+	}
+}
+
+// bodyLimitHandler rejects the request body larger than limit before it is buffered by the adaptor.
+func bodyLimitHandler(limit int64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		if r.Body != nil && r.Body != http.NoBody {
+			b, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+			if err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+					return
+				}
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(b))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Listen binds server address, it returns nil listener for fiber prefork,
+// because prefork must bind the address by itself in every child process.
+func (s *HTTPServer) Listen() (net.Listener, error) {
+	if s.Std == nil && s.TLS == nil && s.App.Config().Prefork {
+		return nil, nil
+	}
+	return net.Listen("tcp", s.Config.Address)
+}
+
+// Serve serves the server on the given listener, it blocks until the server is stopped.
+func (s *HTTPServer) Serve(ln net.Listener) error {
+	if ln == nil {
+		return s.App.Listen(s.Config.Address)
+	}
+
+	if s.Std == nil {
+		if s.TLS != nil {
+			ln = tls.NewListener(ln, s.TLS)
+		}
+		return s.App.Listener(ln)
+	}
+
+	var err error
+	if s.TLS != nil {
+		err = s.Std.ServeTLS(ln, "", "")
+	} else {
+		err = s.Std.Serve(ln)
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	if s.Std != nil {
+		return s.Std.Shutdown(ctx)
+	}
+	return s.App.ShutdownWithContext(ctx)
+}
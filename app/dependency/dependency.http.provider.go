@@ -0,0 +1,135 @@
+package dependency
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xclient"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type ProvideProviderClientsParam struct {
+	fx.In
+
+	Lc     fx.Lifecycle
+	Cfg    config.Cfg
+	Log    *xlog.DebugLogger
+	Tracer trace.Tracer
+	Meter  metric.Meter
+	Redis  *redis.Client
+	Locker *redislock.Client
+}
+
+// ProvideProviderClients builds outgoing http client of every 'provider' config, the client is injected by name
+// through 'xclient.Provide("<key>")' or looked up from the returned registry.
+func ProvideProviderClients(p ProvideProviderClientsParam) (*xclient.Clients, error) {
+	metrics, err := xclient.NewMetrics(p.Meter)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		keys    = make([]string, 0, len(p.Cfg.Provider))
+		clients = make([]*xclient.Client, 0, len(p.Cfg.Provider))
+		opts    = xclient.Options{
+			Tracer:  p.Tracer,
+			Metrics: metrics,
+			Logger:  xlog.NewRestyV3Logger(xlog.NewLogger(p.Log.Logger), p.Log.Redactor),
+			Redis:   p.Redis,
+			Locker:  p.Locker,
+		}
+	)
+
+	for key := range p.Cfg.Provider {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		cfg := NewProviderClientConfig(key, p.Cfg.Provider[key])
+		if cfg.OAuth2.Enabled && (cfg.OAuth2.TokenURL == "" || cfg.OAuth2.ClientID == "") {
+			return nil, fmt.Errorf("provider '%s': oauth2 requires 'oauth2.token.url' and 'options.client.id' config", key)
+		}
+		if s := cfg.OAuth2.AuthStyle; s != "" && s != xclient.AuthStyleHeader && s != xclient.AuthStyleBody {
+			return nil, fmt.Errorf("provider '%s': unknown oauth2 auth style '%s'", key, s)
+		}
+
+		cfg.OAuth2.Namespace = p.Cfg.App.Env
+		clients = append(clients, xclient.New(cfg, opts))
+	}
+
+	c := xclient.NewClients(clients...)
+	p.Lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return c.Close()
+		},
+	})
+
+	return c, nil
+}
+
+func NewProviderClientConfig(name string, p config.Provider) xclient.Config {
+	return xclient.Config{
+		Name:                  name,
+		BaseURL:               p.BaseUrl,
+		Debug:                 p.Debug,
+		Timeout:               time.Duration(p.Timeout.Request) * time.Second,
+		DialTimeout:           time.Duration(p.Timeout.Dial) * time.Second,
+		ResponseHeaderTimeout: time.Duration(p.Timeout.ResponseHeader) * time.Second,
+		Retry: xclient.RetryConfig{
+			Count:       p.Retry.Count,
+			WaitTime:    time.Duration(p.Retry.WaitTime) * time.Millisecond,
+			MaxWaitTime: time.Duration(p.Retry.MaxWaitTime) * time.Millisecond,
+		},
+		Breaker: xclient.BreakerConfig{
+			Enabled:          p.Breaker.Enabled,
+			FailureThreshold: p.Breaker.FailureThreshold,
+			SuccessThreshold: p.Breaker.SuccessThreshold,
+			OpenTimeout:      time.Duration(p.Breaker.OpenTimeout) * time.Second,
+			HalfOpenRequests: p.Breaker.HalfOpenRequests,
+		},
+		OAuth2: xclient.OAuth2Config{
+			Enabled:       p.OAuth2.Enabled,
+			TokenURL:      p.OAuth2.TokenURL,
+			ClientID:      p.Options["client.id"],
+			ClientSecret:  p.Options["client.secret"],
+			Scopes:        p.OAuth2.Scopes,
+			AuthStyle:     p.OAuth2.AuthStyle,
+			RefreshBefore: time.Duration(p.OAuth2.RefreshBefore) * time.Second,
+			LockTimeout:   time.Duration(p.OAuth2.LockTimeout) * time.Second,
+		},
+	}
+}
+
+func InvokeProviderClientMetric(meter metric.Meter, clients *xclient.Clients) error {
+	state, err := meter.Int64ObservableGauge(
+		"http.client.breaker.state",
+		metric.WithDescription("Circuit breaker state per provider, 0 is closed, 1 is open and 2 is half-open"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for _, name := range clients.Names() {
+			c, err := clients.Get(name)
+			if err != nil || c.Breaker == nil {
+				continue
+			}
+			o.ObserveInt64(state, int64(c.Breaker.State()), metric.WithAttributes(attribute.String("provider", name)))
+		}
+		return nil
+	}, state)
+
+	return err
+}
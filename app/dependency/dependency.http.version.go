@@ -0,0 +1,72 @@
+package dependency
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/infra/http/middleware"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+)
+
+// NewVersionAPIs creates huma api of every 'api.versions' config on the same fiber app,
+// its operations are prefixed by the version prefix and documented in the version openapi document.
+func NewVersionAPIs(app *fiber.App, svr config.Server, cfg config.API, registry *middleware.OperationRegistry) (map[string]huma.API, error) {
+	apis := make(map[string]huma.API, len(cfg.Versions))
+
+	for key, v := range cfg.Versions {
+		if v.Prefix == "" {
+			return nil, fmt.Errorf("api version '%s' must declare 'prefix'", key)
+		}
+
+		deprecation, err := NewAPIVersionDeprecation(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api version '%s': %w", key, err)
+		}
+
+		api := humafiber.New(app, NewHumaVersionConfig(svr, key, v))
+
+		group := huma.NewGroup(api, v.Prefix)
+		group.UseModifier(xhuma.VersionModifier(key, deprecation))
+		registry.Use(group)
+
+		apis[key] = group
+	}
+
+	return apis, nil
+}
+
+// NewAPIVersionDeprecation returns nil when the version is neither deprecated nor has sunset date.
+func NewAPIVersionDeprecation(v config.APIVersion) (*xhuma.Deprecation, error) {
+	if v.Deprecation == "" && v.Sunset == "" {
+		return nil, nil
+	}
+
+	var (
+		d   = xhuma.Deprecation{Link: v.Link}
+		err error
+	)
+
+	if d.Date, err = parseAPIVersionDate(v.Deprecation); err != nil {
+		return nil, fmt.Errorf("failed to parse 'deprecation': %w", err)
+	}
+	if d.Sunset, err = parseAPIVersionDate(v.Sunset); err != nil {
+		return nil, fmt.Errorf("failed to parse 'sunset': %w", err)
+	}
+
+	return &d, nil
+}
+
+func parseAPIVersionDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
@@ -1,6 +1,8 @@
 package dependency
 
 import (
+	"fmt"
+
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/infra/http/middleware"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
@@ -8,7 +10,7 @@ import (
 	_ "github.com/danielgtaylor/huma/v2/formats/cbor"
 )
 
-func ProvideHumaConfig(s config.Server) huma.Config {
+func NewHumaConfig(s config.Server, withMonitor bool) huma.Config {
 	var (
 		schemaPrefix = "#/components/schemas/"
 		schemasPath  = "/schemas"
@@ -23,8 +25,13 @@ func ProvideHumaConfig(s config.Server) huma.Config {
 		}
 	)
 
+	// Register Error Catalog into OAPI
+	xhuma.RegisterErrorCatalogOAPI(&oapi)
+
 	// Register Fiber Monitor Metric into OAPI
-	middleware.RegisterMiddlewareMonitorOAPI(&oapi)
+	if withMonitor {
+		middleware.RegisterMiddlewareMonitorOAPI(&oapi)
+	}
 
 	return huma.Config{
 		OpenAPI:       &oapi,
@@ -35,9 +42,26 @@ func ProvideHumaConfig(s config.Server) huma.Config {
 		DefaultFormat: "application/json",
 		CreateHooks: []func(huma.Config) huma.Config{
 			func(c huma.Config) huma.Config {
-				c.Transformers = append(c.Transformers, xhuma.TransformerTraceIdSetter)
+				c.Transformers = append(c.Transformers, xhuma.TransformerTraceIdSetter, xhuma.TransformerLocalize)
 				return c
 			},
 		},
 	}
 }
+
+// NewHumaVersionConfig serves the openapi document of the version at '<prefix>/openapi' and '<prefix>/docs'.
+func NewHumaVersionConfig(s config.Server, version string, v config.APIVersion) huma.Config {
+	c := NewHumaConfig(s, false)
+
+	if s.OAPI.Info != nil {
+		info := *s.OAPI.Info
+		info.Title = fmt.Sprintf("%s (%s)", info.Title, version)
+		c.OpenAPI.Info = &info
+	}
+
+	c.OpenAPIPath = v.Prefix + c.OpenAPIPath
+	c.DocsPath = v.Prefix + c.DocsPath
+	c.SchemasPath = v.Prefix + c.SchemasPath
+
+	return c
+}
@@ -0,0 +1,20 @@
+package dependency
+
+import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xi18n"
+)
+
+func ProvideI18n(c config.Cfg) (*xi18n.Bundle, error) {
+	dir := c.I18n.Dir
+	if dir == "" {
+		dir = "./storage/i18n"
+	}
+
+	return xi18n.Load(dir, c.I18n.Fallback)
+}
+
+// InvokeI18n sets the bundle as default, error response is localized outside of fx graph (i.e: huma error).
+func InvokeI18n(b *xi18n.Bundle) {
+	xi18n.SetDefault(b)
+}
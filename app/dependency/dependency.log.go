@@ -35,7 +35,7 @@ type DebugLoggerParamFx struct {
 	LoggerProvider otelog.LoggerProvider `optional:"true"`
 }
 
-func ProvideDebugLogger(p DebugLoggerParamFx) *xlog.DebugLogger {
+func ProvideDebugLogger(p DebugLoggerParamFx) (*xlog.DebugLogger, error) {
 	var (
 		filename          = path.Join(p.Cfg.Log.BasePath, p.Cfg.Log.File.Name)
 		rotation          = p.Cfg.Log.File.Rotation
@@ -87,7 +87,23 @@ func ProvideDebugLogger(p DebugLoggerParamFx) *xlog.DebugLogger {
 		xlog.SetField("appPid", os.Getpid()),
 	)
 
+	redact := p.Cfg.Log.Redact
+	redactor, err := xlog.NewRedactor(xlog.RedactRule{
+		Mask:       redact.Mask,
+		Headers:    redact.Headers,
+		JSONPaths:  redact.JSONPaths,
+		FormFields: redact.FormFields,
+		Patterns:   redact.Patterns,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if redactor.Enabled() {
+		debugLog.Redactor = redactor
+	}
+
 	debugLogger = &debugLog
 
-	return &debugLog
+	return &debugLog, nil
 }
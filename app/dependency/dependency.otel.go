@@ -13,19 +13,24 @@ import (
 	"go.opentelemetry.io/otel/log/noop"
 	"go.opentelemetry.io/otel/metric"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtracer"
 )
 
 func ProvideOtelConfig(c config.Cfg, s config.Server) xtracer.Config {
 	return xtracer.Config{
-		Tracer: c.Otel.Tracer,
-		Metric: c.Otel.Metric,
-		Logs:   c.Otel.Logs,
+		Tracer:     c.Otel.Tracer,
+		Metric:     c.Otel.Metric,
+		Logs:       c.Otel.Logs,
+		Prometheus: c.Otel.Prometheus.Enabled,
 
 		ModuleName:    fmt.Sprintf("%s/%s", c.App.Project, s.Name),
 		ServerName:    fmt.Sprintf("%s/%s", c.App.Project, s.Name),
@@ -57,6 +62,10 @@ func ProvideOtelGrpcClient(c xtracer.Config) (*xtracer.GrpcClient, error) {
 	return client, nil
 }
 
+func ProvideOtelPrometheus(c xtracer.Config) (*xtracer.Prometheus, error) {
+	return xtracer.NewPrometheus(c)
+}
+
 type OtelParamFx struct {
 	fx.In
 
@@ -64,6 +73,7 @@ type OtelParamFx struct {
 	Cfg        xtracer.Config
 	GrpcClient *xtracer.GrpcClient
 	Resource   *resource.Resource
+	Prometheus *xtracer.Prometheus
 }
 
 func ProvideOtelTracer(p OtelParamFx) (trace.Tracer, error) {
@@ -82,15 +92,26 @@ func ProvideOtelTracer(p OtelParamFx) (trace.Tracer, error) {
 }
 
 func ProvideOtelMetric(p OtelParamFx) (metric.Meter, error) {
-	if !p.Cfg.Metric {
+	if !p.Cfg.Metric && !p.Prometheus.Enabled() {
 		return otel.Meter(p.Cfg.ModuleName), nil
 	}
 
+	var readers []sdkmetric.Reader
+	if p.Prometheus.Enabled() {
+		readers = append(readers, p.Prometheus.Reader)
+	}
+
+	var conn *grpc.ClientConn
+	if p.GrpcClient != nil {
+		conn = p.GrpcClient.ClientConn
+	}
+
 	meter, shutdownFn, err := xtracer.NewOtelMeter(
 		context.Background(),
 		p.Cfg,
 		p.Resource,
-		p.GrpcClient.ClientConn,
+		conn,
+		readers...,
 	)
 	if err != nil {
 		return nil, err
@@ -101,6 +122,15 @@ func ProvideOtelMetric(p OtelParamFx) (metric.Meter, error) {
 	return meter, nil
 }
 
+// InvokeOtelRuntimeMetric records go runtime metrics once meter provider is set,
+// meter is required here only to make sure global meter provider is already set.
+func InvokeOtelRuntimeMetric(c xtracer.Config, _ metric.Meter) error {
+	if !c.Metric && !c.Prometheus {
+		return nil
+	}
+	return xtracer.StartRuntimeMetric(otel.GetMeterProvider())
+}
+
 func ProvideOtelLog(p OtelParamFx) (log.LoggerProvider, error) {
 	if !p.Cfg.Logs {
 		return noop.NewLoggerProvider(), nil
@@ -151,3 +181,30 @@ func ProvideOtelLog(p OtelParamFx) (log.LoggerProvider, error) {
 
 	return logProvider, nil
 }
+
+// ProvideOtelHealthCheck checks the collector grpc connection state, the check is skipped
+// when every otel signal is disabled, because there is no collector connection.
+func ProvideOtelHealthCheck(c config.Cfg, client *xtracer.GrpcClient) xhealth.Check {
+	if client == nil || client.ClientConn == nil {
+		return xhealth.Check{Name: "otel.collector"}
+	}
+
+	return NewHealthCheck(c, "otel.collector", []xhealth.Kind{xhealth.KindReadiness}, func(ctx context.Context) error {
+		conn := client.ClientConn
+		conn.Connect()
+
+		for {
+			state := conn.GetState()
+			switch state {
+			case connectivity.Ready:
+				return nil
+			case connectivity.Shutdown:
+				return errors.New("otel collector connection is shutdown")
+			}
+
+			if !conn.WaitForStateChange(ctx, state) {
+				return fmt.Errorf("otel collector is unreachable, last connection state is '%s'", state)
+			}
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package dependency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgraceful"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpush"
+)
+
+// ProvidePushHub provides hub which fans out sse and websocket messages,
+// it is subscribed into redis pub/sub when 'push.redis' is enabled, so every instance receives them.
+func ProvidePushHub(c config.Cfg, rdb *redis.Client, lc fx.Lifecycle) *xpush.Hub {
+	var client *redis.Client
+	if c.Push.Redis {
+		client = rdb
+	}
+
+	hub := xpush.NewHub(xpush.Options{Buffer: c.Push.Buffer, Channel: c.Push.Channel}, client)
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return hub.Start(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			return hub.Close()
+		},
+	})
+
+	return hub
+}
+
+func ProvidePushStreamOptions(c config.Cfg, tracker *xgraceful.Tracker) xpush.StreamOptions {
+	return xpush.StreamOptions{
+		Heartbeat: time.Duration(c.Push.Heartbeat) * time.Second,
+		Done:      tracker.Draining(),
+	}
+}
+
+func InvokePushMetric(meter metric.Meter, hub *xpush.Hub) error {
+	var errs []error
+
+	subscribers, err := meter.Int64ObservableGauge(
+		"push.subscribers",
+		metric.WithDescription("Number of local sse and websocket subscribers"),
+	)
+	errs = append(errs, err)
+
+	dropped, err := meter.Int64ObservableCounter(
+		"push.messages.dropped",
+		metric.WithDescription("Number of messages which are dropped for slow subscribers"),
+	)
+	errs = append(errs, err)
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(subscribers, int64(hub.Subscribers()))
+		o.ObserveInt64(dropped, hub.Dropped())
+		return nil
+	}, subscribers, dropped)
+
+	return err
+}
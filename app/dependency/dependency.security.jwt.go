@@ -0,0 +1,14 @@
+package dependency
+
+import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xsecurity"
+)
+
+// # NOTE
+//
+//	Please Generate With Command:
+//	`openssl genpkey -algorithm RSA -pkeyopt rsa_keygen_bits:2048 -out storage/keys/jwt.pem`
+func ProvideJWTManager(c config.Cfg) (xsecurity.JWTManager, error) {
+	return xsecurity.NewJWTManager(c.Security.JWT.PrivateKey, c.Security.JWT.Issuer)
+}
@@ -0,0 +1,56 @@
+package dependency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xstorage"
+)
+
+// placeholderLocalStorageSecret is the secret of config example, presigned url signed by it can be forged by anyone.
+const placeholderLocalStorageSecret = "change-me-local-storage-secret"
+
+func ProvideStorage(c config.Cfg) (xstorage.Storage, error) {
+	cfg := c.Storage
+
+	switch cfg.Driver {
+	case "", "local":
+		if cfg.Local.Secret == placeholderLocalStorageSecret {
+			return nil, errors.New("local storage secret must be changed from the placeholder of config example: 'storage.local.secret'")
+		}
+		return xstorage.NewLocalStorage(xstorage.LocalConfig{
+			Root:    cfg.Local.Root,
+			BaseURL: cfg.Local.BaseURL,
+			Secret:  cfg.Local.Secret,
+		})
+	case "s3":
+		return xstorage.NewS3Storage(xstorage.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			UseSSL:    cfg.S3.UseSSL,
+			PathStyle: cfg.S3.PathStyle,
+		})
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", xstorage.ErrUnsupportedDriver, cfg.Driver)
+}
+
+func ProvideStorageHealthCheck(c config.Cfg, s xstorage.Storage) xhealth.Check {
+	return NewHealthCheck(c, "storage", dependencyCheckKinds, func(ctx context.Context) error {
+		switch st := s.(type) {
+		case *xstorage.S3Storage:
+			return st.Ping(ctx)
+		case *xstorage.LocalStorage:
+			_, err := os.Stat(c.Storage.Local.Root)
+			return err
+		}
+		return nil
+	})
+}
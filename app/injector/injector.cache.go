@@ -2,6 +2,7 @@ package injector
 
 import (
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/dependency"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
 	"go.uber.org/fx"
 )
 
@@ -10,12 +11,14 @@ var (
 		fx.Module("dependency:cache",
 			fx.Provide(dependency.ProvideRedis),
 			fx.Provide(dependency.ProvideRedisLock),
+			fx.Provide(xhealth.AnnotateCheckAs(dependency.ProvideRedisHealthCheck)),
 		),
 	)
 
 	CacheStartUp = fx.Options(
 		fx.Module("dependency:cache:startup",
 			fx.Invoke(dependency.InvokeRedis),
+			fx.Invoke(dependency.InvokeRedisMetric),
 		),
 	)
 )
@@ -2,6 +2,7 @@ package injector
 
 import (
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/dependency"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
 	"go.uber.org/fx"
 )
 
@@ -10,6 +11,8 @@ var (
 		fx.Module("dependency:database",
 			fx.Provide(dependency.ProvidePostgres),
 			fx.Provide(dependency.ProvidePostgresSQLDB),
+			fx.Provide(xhealth.AnnotateCheckAs(dependency.ProvidePostgresHealthCheck)),
+			fx.Provide(xhealth.AnnotateCheckAs(dependency.ProvidePostgresPoolHealthCheck)),
 		),
 	)
 
@@ -28,6 +31,7 @@ var (
 	DatabaseStartUp = fx.Options(
 		fx.Module("dependency:database:startup",
 			fx.Invoke(dependency.InvokePostgres),
+			fx.Invoke(dependency.InvokePostgresMetric),
 			fx.Invoke(dependency.InvokeMigrations),
 			fx.Invoke(dependency.InvokeSeeders),
 		),
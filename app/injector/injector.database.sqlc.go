@@ -1,7 +1,9 @@
 package injector
 
 import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/gen/sqlc"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtenant"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/fx"
 )
@@ -9,7 +11,12 @@ import (
 var (
 	RepoGenerationSqlc = fx.Options(
 		fx.Module("dependency:database:sqlc:repo",
-			fx.Provide(func(db *pgxpool.Pool) sqlc.DBTX {
+			fx.Provide(func(c config.Cfg, db *pgxpool.Pool) sqlc.DBTX {
+				// with row level security, the query is scoped by tenant which is bound on connection acquire,
+				// so it must never run without tenant
+				if c.Tenant.Enabled && (c.Tenant.Required || c.Tenant.DB.RowLevelSecurity) {
+					return xtenant.NewGuard(db)
+				}
 				return db
 			}),
 			fx.Provide(sqlc.New),
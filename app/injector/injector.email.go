@@ -2,6 +2,7 @@ package injector
 
 import (
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/dependency"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
 	"go.uber.org/fx"
 )
 
@@ -9,6 +10,7 @@ var (
 	GlobalEmail = fx.Options(
 		fx.Module("dependency:global:email",
 			fx.Provide(dependency.ProvideXGmail),
+			fx.Provide(xhealth.AnnotateCheckAs(dependency.ProvideXGmailHealthCheck)),
 		),
 	)
 )
@@ -1,6 +1,8 @@
 package injector
 
 import (
+	"time"
+
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
 	"go.uber.org/fx"
 )
@@ -8,5 +10,8 @@ import (
 var (
 	Fx = fx.Options(
 		fx.WithLogger(xlog.SetupFxLogger),
+
+		// must be greater than 'shutdown.drain.period' plus time to complete in-flight requests
+		fx.StopTimeout(60*time.Second),
 	)
 )
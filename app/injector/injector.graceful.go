@@ -0,0 +1,15 @@
+package injector
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/dependency"
+)
+
+var (
+	Graceful = fx.Options(
+		fx.Module("dependency:graceful",
+			fx.Provide(dependency.ProvideGracefulTracker),
+		),
+	)
+)
@@ -0,0 +1,15 @@
+package injector
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/dependency"
+)
+
+var (
+	Grpc = fx.Options(
+		fx.Module("grpc:server",
+			fx.Provide(dependency.ProvideGRPCServers),
+		),
+	)
+)
@@ -0,0 +1,21 @@
+package injector
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/dependency"
+)
+
+var (
+	Health = fx.Options(
+		fx.Module("dependency:health",
+			fx.Provide(dependency.ProvideHealthRegistry),
+		),
+	)
+
+	HealthStartUp = fx.Options(
+		fx.Module("dependency:health:startup",
+			fx.Invoke(dependency.InvokeHealthStartup),
+		),
+	)
+)
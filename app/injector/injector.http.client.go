@@ -10,6 +10,8 @@ var (
 	Resty = fx.Options(
 		fx.Module("dependency:resty",
 			fx.Provide(dependency.ProvideHttpClient),
+			fx.Provide(dependency.ProvideProviderClients),
+			fx.Invoke(dependency.InvokeProviderClientMetric),
 		),
 	)
 )
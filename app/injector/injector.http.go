@@ -9,10 +9,7 @@ import (
 var (
 	Http = fx.Options(
 		fx.Module("http:server",
-			fx.Provide(dependency.ProvideHumaConfig),
-			fx.Provide(dependency.ProvideFiberConfig),
-			fx.Provide(dependency.ProvideFiber),
-			fx.Provide(dependency.ProvideHumaFiber),
+			fx.Provide(dependency.ProvideHTTPServers),
 		),
 	)
 
@@ -0,0 +1,16 @@
+package injector
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/dependency"
+)
+
+var (
+	I18n = fx.Options(
+		fx.Module("dependency:i18n",
+			fx.Provide(dependency.ProvideI18n),
+			fx.Invoke(dependency.InvokeI18n),
+		),
+	)
+)
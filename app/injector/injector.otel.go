@@ -4,6 +4,7 @@ import (
 	"go.uber.org/fx"
 
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/dependency"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
 )
 
 var (
@@ -12,9 +13,12 @@ var (
 			fx.Provide(dependency.ProvideOtelConfig),
 			fx.Provide(dependency.ProvideOtelGrpcClient),
 			fx.Provide(dependency.ProvideOtelResource),
+			fx.Provide(dependency.ProvideOtelPrometheus),
 			fx.Provide(dependency.ProvideOtelTracer),
 			fx.Provide(dependency.ProvideOtelMetric),
 			fx.Provide(dependency.ProvideOtelLog),
+			fx.Provide(xhealth.AnnotateCheckAs(dependency.ProvideOtelHealthCheck)),
+			fx.Invoke(dependency.InvokeOtelRuntimeMetric),
 		),
 	)
 )
@@ -0,0 +1,17 @@
+package injector
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/dependency"
+)
+
+var (
+	Push = fx.Options(
+		fx.Module("dependency:push",
+			fx.Provide(dependency.ProvidePushHub),
+			fx.Provide(dependency.ProvidePushStreamOptions),
+			fx.Invoke(dependency.InvokePushMetric),
+		),
+	)
+)
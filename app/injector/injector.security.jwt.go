@@ -0,0 +1,14 @@
+package injector
+
+import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/dependency"
+	"go.uber.org/fx"
+)
+
+var (
+	SecurityJWT = fx.Options(
+		fx.Module("dependency:security:jwt",
+			fx.Provide(dependency.ProvideJWTManager),
+		),
+	)
+)
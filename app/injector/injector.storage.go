@@ -0,0 +1,17 @@
+package injector
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/dependency"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
+)
+
+var (
+	Storage = fx.Options(
+		fx.Module("dependency:storage",
+			fx.Provide(dependency.ProvideStorage),
+			fx.Provide(xhealth.AnnotateCheckAs(dependency.ProvideStorageHealthCheck)),
+		),
+	)
+)
@@ -7,6 +7,7 @@ import (
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/app/injector"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/internal"
 
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/infra/grpc/interceptor"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/infra/http/middleware"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/infra/sdk"
 )
@@ -18,7 +19,11 @@ func main() {
 		injector.GlobalConfig,
 		injector.GlobalLogger,
 		injector.GlobalEmail,
+		injector.I18n,
+		injector.SecurityJWT,
 		injector.OtelSetup,
+		injector.Graceful,
+		injector.Health,
 
 		// Cache
 		injector.Cache,
@@ -30,25 +35,42 @@ func main() {
 		injector.GormGenDatabase,
 		injector.DatabaseStartUp,
 
+		// Storage
+		injector.Storage,
+
+		// Push
+		injector.Push,
+
 		// Repo SQLC Generator
 		injector.RepoGenerationSqlc,
 
+		// Outgoing HTTP Client
+		injector.Resty,
+
 		// SDK
 		sdk.Modules,
 
 		// HTTP Middleware
 		middleware.GlobalModules,
+		middleware.OperationModules,
 		middleware.PrivateModules,
 
+		// gRPC Interceptor
+		interceptor.GlobalModules,
+
 		// HTTP
 		injector.CoreServer,
 		injector.Http,
+		injector.Grpc,
 		injector.HttpStartUp,
 
 		// Internal Modules
 		internal.RepoModules,
 		internal.ServiceModules,
 		internal.HandlerModules,
+
+		// Must be the last startup, see dependency.InvokeHealthStartup
+		injector.HealthStartUp,
 	).Run()
 
 	defer dependency.RotateLog()
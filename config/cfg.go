@@ -29,16 +29,29 @@ func ProvideConfig() (Cfg, error) {
 }
 
 type Cfg struct {
-	App      App                 `yaml:"app"`
-	Otel     Otel                `yaml:"otel"`
-	Server   map[string]Server   `yaml:"server"`
-	SMTP     map[string]SMTP     `yaml:"smtp"`
-	Template map[string]string   `yaml:"template"`
-	DB       map[string]DB       `yaml:"db"`
-	Cache    map[string]Cache    `yaml:"cache"`
-	Log      Log                 `yaml:"log"`
-	Provider map[string]Provider `yaml:"provider"`
-	Security Security            `yaml:"security"`
+	App         App                 `yaml:"app"`
+	Otel        Otel                `yaml:"otel"`
+	Server      map[string]Server   `yaml:"server"`
+	SMTP        map[string]SMTP     `yaml:"smtp"`
+	Template    map[string]string   `yaml:"template"`
+	DB          map[string]DB       `yaml:"db"`
+	Cache       map[string]Cache    `yaml:"cache"`
+	Log         Log                 `yaml:"log"`
+	Provider    map[string]Provider `yaml:"provider"`
+	Security    Security            `yaml:"security"`
+	Tenant      Tenant              `yaml:"tenant"`
+	Limit       Limit               `yaml:"limit"`
+	Idempotency Idempotency         `yaml:"idempotency"`
+	Shutdown    Shutdown            `yaml:"shutdown"`
+	Health      Health              `yaml:"health"`
+	Timeout     Timeout             `yaml:"timeout"`
+	Storage     Storage             `yaml:"storage"`
+	Push        Push                `yaml:"push"`
+	API         API                 `yaml:"api"`
+	Middleware  Middleware          `yaml:"middleware"`
+	Crash       Crash               `yaml:"crash"`
+	I18n        I18n                `yaml:"i18n"`
+	Audit       Audit               `yaml:"audit"`
 }
 
 type App struct {
@@ -48,11 +61,16 @@ type App struct {
 }
 
 type Otel struct {
-	Tracer  bool        `yaml:"tracer"`
-	Metric  bool        `yaml:"metric"`
-	Logs    bool        `yaml:"logs"`
-	Server  OtelServer  `yaml:"server"`
-	Options OtelOptions `yaml:"options"`
+	Tracer     bool           `yaml:"tracer"`
+	Metric     bool           `yaml:"metric"`
+	Logs       bool           `yaml:"logs"`
+	Prometheus OtelPrometheus `yaml:"prometheus"`
+	Server     OtelServer     `yaml:"server"`
+	Options    OtelOptions    `yaml:"options"`
+}
+
+type OtelPrometheus struct {
+	Enabled bool `yaml:"enabled"`
 }
 
 type OtelServer struct {
@@ -65,13 +83,34 @@ type OtelOptions struct {
 }
 
 type Server struct {
-	Name       string            `yaml:"name"`
-	Host       string            `yaml:"host"`
-	Port       int               `yaml:"port"`
-	Address    string            `yaml:"address"`
-	Domain     string            `yaml:"domain"`
-	Additional map[string]string `yaml:"additional"`
-	OAPI       ServerOpenAPI     `yaml:"oapi"`
+	Disabled      bool              `yaml:"disabled"`
+	Protocol      string            `yaml:"protocol"`
+	Name          string            `yaml:"name"`
+	Host          string            `yaml:"host"`
+	Port          int               `yaml:"port"`
+	Address       string            `yaml:"address"`
+	Domain        string            `yaml:"domain"`
+	HandlerGroups []string          `yaml:"handler.groups"`
+	Middlewares   []string          `yaml:"middlewares"`
+	TLS           ServerTLS         `yaml:"tls"`
+	HTTP2         ServerHTTP2       `yaml:"http2"`
+	Additional    map[string]string `yaml:"additional"`
+	OAPI          ServerOpenAPI     `yaml:"oapi"`
+}
+
+type ServerTLS struct {
+	Enabled        bool     `yaml:"enabled"`
+	CertFile       string   `yaml:"cert.file"`
+	KeyFile        string   `yaml:"key.file"`
+	ClientCAFile   string   `yaml:"client.ca.file"`
+	ClientAuth     string   `yaml:"client.auth"`
+	MinVersion     string   `yaml:"min.version"`
+	CipherSuites   []string `yaml:"cipher.suites"`
+	ReloadInterval int      `yaml:"reload.interval"`
+}
+
+type ServerHTTP2 struct {
+	Enabled bool `yaml:"enabled"`
 }
 
 type ServerOpenAPI struct {
@@ -139,10 +178,33 @@ type DBCredential struct {
 }
 
 type Log struct {
-	BasePath      string  `yaml:"base.path"`
-	Level         int     `yaml:"level"`
-	ConsoleFormat string  `yaml:"console.format"`
-	File          LogFile `yaml:"file"`
+	BasePath      string    `yaml:"base.path"`
+	Level         int       `yaml:"level"`
+	ConsoleFormat string    `yaml:"console.format"`
+	File          LogFile   `yaml:"file"`
+	Redact        LogRedact `yaml:"redact"`
+	Body          LogBody   `yaml:"body"`
+}
+
+type LogBody struct {
+	MaxSize  int             `yaml:"max.size"`
+	Marker   string          `yaml:"marker"`
+	Sampling LogBodySampling `yaml:"sampling"`
+}
+
+type LogBodySampling struct {
+	Enabled       bool               `yaml:"enabled"`
+	Rate          float64            `yaml:"rate"`
+	SlowThreshold int                `yaml:"slow.threshold"`
+	Routes        map[string]float64 `yaml:"routes"`
+}
+
+type LogRedact struct {
+	Mask       string            `yaml:"mask"`
+	Headers    []string          `yaml:"headers"`
+	JSONPaths  []string          `yaml:"json.paths"`
+	FormFields []string          `yaml:"form.fields"`
+	Patterns   map[string]string `yaml:"patterns"`
 }
 
 type LogFile struct {
@@ -162,9 +224,209 @@ type LogRotation struct {
 
 type Security struct {
 	AESKey map[string]string `yaml:"aes.key"`
+	JWT    SecurityJWT       `yaml:"jwt"`
+}
+
+type SecurityJWT struct {
+	PrivateKey string `yaml:"private.key"`
+	Issuer     string `yaml:"issuer"`
 }
 
 type Provider struct {
 	BaseUrl string            `yaml:"base.url"`
+	Debug   bool              `yaml:"debug"`
+	Timeout ProviderTimeout   `yaml:"timeout"`
+	Retry   ProviderRetry     `yaml:"retry"`
+	Breaker ProviderBreaker   `yaml:"breaker"`
+	OAuth2  ProviderOAuth2    `yaml:"oauth2"`
 	Options map[string]string `yaml:"options"`
 }
+
+type ProviderTimeout struct {
+	Request        int `yaml:"request"`
+	Dial           int `yaml:"dial"`
+	ResponseHeader int `yaml:"response.header"`
+}
+
+type ProviderRetry struct {
+	Count       int `yaml:"count"`
+	WaitTime    int `yaml:"wait.time"`
+	MaxWaitTime int `yaml:"max.wait.time"`
+}
+
+type ProviderBreaker struct {
+	Enabled          bool `yaml:"enabled"`
+	FailureThreshold int  `yaml:"failure.threshold"`
+	SuccessThreshold int  `yaml:"success.threshold"`
+	OpenTimeout      int  `yaml:"open.timeout"`
+	HalfOpenRequests int  `yaml:"half.open.requests"`
+}
+
+type ProviderOAuth2 struct {
+	Enabled       bool     `yaml:"enabled"`
+	TokenURL      string   `yaml:"token.url"`
+	Scopes        []string `yaml:"scopes"`
+	AuthStyle     string   `yaml:"auth.style"`
+	RefreshBefore int      `yaml:"refresh.before"`
+	LockTimeout   int      `yaml:"lock.timeout"`
+}
+
+type Tenant struct {
+	Enabled  bool     `yaml:"enabled"`
+	Required bool     `yaml:"required"`
+	Sources  []string `yaml:"sources"`
+	Header   string   `yaml:"header"`
+	Domain   string   `yaml:"domain"`
+	Claim    string   `yaml:"claim"`
+	DB       TenantDB `yaml:"db"`
+}
+
+type TenantDB struct {
+	RowLevelSecurity bool `yaml:"row.level.security"`
+}
+
+type Limit struct {
+	Rate        RateLimit        `yaml:"rate"`
+	Concurrency ConcurrencyLimit `yaml:"concurrency"`
+}
+
+type RateLimit struct {
+	Enabled      bool                     `yaml:"enabled"`
+	APIKeyHeader string                   `yaml:"api.key.header"`
+	UserClaim    string                   `yaml:"user.claim"`
+	Default      RateLimitRule            `yaml:"default"`
+	Operations   map[string]RateLimitRule `yaml:"operations"`
+}
+
+type RateLimitRule struct {
+	Disabled  bool   `yaml:"disabled"`
+	Algorithm string `yaml:"algorithm"`
+	KeyBy     string `yaml:"key.by"`
+	Limit     int    `yaml:"limit"`
+	Window    int    `yaml:"window"`
+}
+
+type ConcurrencyLimit struct {
+	Enabled          bool     `yaml:"enabled"`
+	Initial          int      `yaml:"initial"`
+	Min              int      `yaml:"min"`
+	Max              int      `yaml:"max"`
+	LatencyThreshold int      `yaml:"latency.threshold"`
+	BackoffRatio     float64  `yaml:"backoff.ratio"`
+	RetryAfter       int      `yaml:"retry.after"`
+	Exempt           []string `yaml:"exempt"`
+}
+
+type Idempotency struct {
+	Enabled   bool     `yaml:"enabled"`
+	Header    string   `yaml:"header"`
+	Methods   []string `yaml:"methods"`
+	TTL       int      `yaml:"ttl"`
+	LockTTL   int      `yaml:"lock.ttl"`
+	BodyLimit int      `yaml:"body.limit"`
+}
+
+type Shutdown struct {
+	DrainPeriod int `yaml:"drain.period"`
+}
+
+type Health struct {
+	Timeout        int                    `yaml:"timeout"`
+	CacheTTL       int                    `yaml:"cache.ttl"`
+	PoolSaturation float64                `yaml:"pool.saturation"`
+	Checks         map[string]HealthCheck `yaml:"checks"`
+}
+
+type HealthCheck struct {
+	Disabled bool `yaml:"disabled"`
+	Optional bool `yaml:"optional"`
+	Timeout  int  `yaml:"timeout"`
+	CacheTTL int  `yaml:"cache.ttl"`
+}
+
+type Timeout struct {
+	Default    int            `yaml:"default"`
+	Operations map[string]int `yaml:"operations"`
+}
+
+type Storage struct {
+	Driver        string        `yaml:"driver"`
+	PresignExpiry int           `yaml:"presign.expiry"`
+	Local         StorageLocal  `yaml:"local"`
+	S3            StorageS3     `yaml:"s3"`
+	Upload        StorageUpload `yaml:"upload"`
+}
+
+type StorageLocal struct {
+	Root    string `yaml:"root"`
+	BaseURL string `yaml:"base.url"`
+	Secret  string `yaml:"secret"`
+}
+
+type StorageS3 struct {
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	AccessKey string `yaml:"access.key"`
+	SecretKey string `yaml:"secret.key"`
+	UseSSL    bool   `yaml:"use.ssl"`
+	PathStyle bool   `yaml:"path.style"`
+}
+
+type StorageUpload struct {
+	MaxSize      int64    `yaml:"max.size"`
+	AllowedTypes []string `yaml:"allowed.types"`
+}
+
+type Push struct {
+	Heartbeat int    `yaml:"heartbeat"`
+	Buffer    int    `yaml:"buffer"`
+	Channel   string `yaml:"channel"`
+	Redis     bool   `yaml:"redis"`
+}
+
+type API struct {
+	Versions map[string]APIVersion `yaml:"versions"`
+}
+
+type APIVersion struct {
+	Prefix      string `yaml:"prefix"`
+	Deprecation string `yaml:"deprecation"`
+	Sunset      string `yaml:"sunset"`
+	Link        string `yaml:"link"`
+}
+
+type Middleware struct {
+	SkipPaths []string                    `yaml:"skip.paths"`
+	Global    map[string]MiddlewareGlobal `yaml:"global"`
+	Operation MiddlewareOperation         `yaml:"operation"`
+}
+
+type MiddlewareOperation struct {
+	Defaults []string `yaml:"defaults"`
+}
+
+type MiddlewareGlobal struct {
+	Disabled  bool              `yaml:"disabled"`
+	Order     int               `yaml:"order"`
+	SkipPaths []string          `yaml:"skip.paths"`
+	Options   map[string]string `yaml:"options"`
+}
+
+type Crash struct {
+	Report     bool `yaml:"report"`
+	MaxSamples int  `yaml:"max.samples"`
+}
+
+type Audit struct {
+	Enabled        bool `yaml:"enabled"`
+	BufferSize     int  `yaml:"buffer.size"`
+	BatchSize      int  `yaml:"batch.size"`
+	FlushInterval  int  `yaml:"flush.interval"`
+	EnqueueTimeout int  `yaml:"enqueue.timeout"`
+}
+
+type I18n struct {
+	Dir      string `yaml:"dir"`
+	Fallback string `yaml:"fallback"`
+}
@@ -0,0 +1,12 @@
+package constant
+
+const (
+	// FiberLocalsOperationTimeout holds expired operation timeout (time.Duration), it is read by incoming log.
+	FiberLocalsOperationTimeout = "operation.timeout"
+
+	// FiberLocalsPanic holds recovered panic (xpanic.Report), it is read by incoming log.
+	FiberLocalsPanic = "panic"
+
+	// FiberLocalsLogBody holds operation body logging override (bool), it is read by incoming log.
+	FiberLocalsLogBody = "log.body"
+)
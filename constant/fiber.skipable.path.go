@@ -3,6 +3,7 @@ package constant
 var (
 	FiberSkipablePathFromMiddleware = [...]string{
 		"/favicon.ico", "/openapi.json", "/openapi.yaml",
-		"/docs", "/schemas", "/monitor",
+		"/docs", "/schemas", "/monitor", "/metrics",
+		"/livez", "/readyz", "/startupz",
 	}
 )
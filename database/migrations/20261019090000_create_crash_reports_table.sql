@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE crash_reports(
+  fingerprint VARCHAR PRIMARY KEY,
+  message TEXT NOT NULL,
+  top_frames JSONB NOT NULL,
+  stack JSONB NOT NULL,
+  method VARCHAR NOT NULL,
+  route VARCHAR NOT NULL,
+  count BIGINT NOT NULL,
+  sample_trace_ids TEXT[] NOT NULL,
+  first_seen_at TIMESTAMPTZ NOT NULL,
+  last_seen_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX on crash_reports(last_seen_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE crash_reports;
+-- +goose StatementEnd
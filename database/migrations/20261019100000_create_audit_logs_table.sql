@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE audit_logs(
+  id VARCHAR PRIMARY KEY,
+  actor VARCHAR NOT NULL,
+  tenant_id VARCHAR NOT NULL,
+  action VARCHAR NOT NULL,
+  resource_type VARCHAR NOT NULL,
+  resource_id VARCHAR NOT NULL,
+  diff JSONB NOT NULL,
+  ip VARCHAR NOT NULL,
+  user_agent TEXT NOT NULL,
+  trace_id VARCHAR NOT NULL,
+  occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX on audit_logs(occurred_at DESC);
+CREATE INDEX on audit_logs(resource_type, resource_id);
+CREATE INDEX on audit_logs(actor);
+
+CREATE FUNCTION audit_logs_append_only() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_logs is append-only, % is not allowed', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_logs_append_only_row
+BEFORE UPDATE OR DELETE ON audit_logs
+FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+
+CREATE TRIGGER audit_logs_append_only_truncate
+BEFORE TRUNCATE ON audit_logs
+FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audit_logs;
+DROP FUNCTION audit_logs_append_only;
+-- +goose StatementEnd
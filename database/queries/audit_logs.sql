@@ -0,0 +1,3 @@
+-- name: InsertAuditLog :exec
+INSERT INTO audit_logs (id, actor, tenant_id, action, resource_type, resource_id, diff, ip, user_agent, trace_id, occurred_at)
+VALUES (@id, @actor, @tenant_id, @action, @resource_type, @resource_id, @diff, @ip, @user_agent, @trace_id, @occurred_at);
@@ -0,0 +1,21 @@
+-- name: UpsertCrashReport :one
+INSERT INTO crash_reports (fingerprint, message, top_frames, stack, method, route, count, sample_trace_ids, first_seen_at, last_seen_at)
+VALUES (@fingerprint, @message, @top_frames, @stack, @method, @route, 1, array_remove(ARRAY[@trace_id::text], ''), @seen_at, @seen_at)
+ON CONFLICT (fingerprint) DO UPDATE SET
+  message = EXCLUDED.message,
+  stack = EXCLUDED.stack,
+  method = EXCLUDED.method,
+  route = EXCLUDED.route,
+  count = crash_reports.count + 1,
+  sample_trace_ids = (array_remove(ARRAY[@trace_id::text], '') || crash_reports.sample_trace_ids)[1:@max_samples::int],
+  last_seen_at = EXCLUDED.last_seen_at
+RETURNING *;
+
+-- name: FindCrashReportByFingerprint :one
+SELECT * FROM crash_reports WHERE fingerprint = $1;
+
+-- name: FindCrashReports :many
+SELECT * FROM crash_reports ORDER BY last_seen_at DESC LIMIT $1 OFFSET $2;
+
+-- name: CountCrashReports :one
+SELECT count(*) FROM crash_reports;
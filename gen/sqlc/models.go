@@ -5,10 +5,39 @@
 package sqlc
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AuditLog struct {
+	ID           string    `json:"id"`
+	Actor        string    `json:"actor"`
+	TenantID     string    `json:"tenant_id"`
+	Action       string    `json:"action"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	Diff         []byte    `json:"diff"`
+	Ip           string    `json:"ip"`
+	UserAgent    string    `json:"user_agent"`
+	TraceID      string    `json:"trace_id"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+type CrashReport struct {
+	Fingerprint    string    `json:"fingerprint"`
+	Message        string    `json:"message"`
+	TopFrames      []byte    `json:"top_frames"`
+	Stack          []byte    `json:"stack"`
+	Method         string    `json:"method"`
+	Route          string    `json:"route"`
+	Count          int64     `json:"count"`
+	SampleTraceIds []string  `json:"sample_trace_ids"`
+	FirstSeenAt    time.Time `json:"first_seen_at"`
+	LastSeenAt     time.Time `json:"last_seen_at"`
+}
+
 type ExampleUser struct {
 	ID        uuid.UUID          `json:"id"`
 	Name      string             `json:"name"`
@@ -0,0 +1,35 @@
+package interceptor
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgrpc"
+)
+
+var (
+	// GlobalOrders mirrors http global middleware orders, recovery is placed inside incoming log,
+	// so the recovered panic is logged with its status.
+	GlobalOrders = map[string]int{
+		"graceful":     1,
+		"metrics":      2,
+		"otel.grpc":    3,
+		"trace.id":     4,
+		"incoming.log": 5,
+		"recovery":     6,
+		"auth":         7,
+	}
+
+	GlobalModules = fx.Options(
+		fx.Module("grpc:server:global:interceptor",
+			fx.Provide(
+				xgrpc.AnnotateInterceptorAs(ProvideGraceful),
+				xgrpc.AnnotateInterceptorAs(ProvideMetrics),
+				xgrpc.AnnotateInterceptorAs(ProvideOtel),
+				xgrpc.AnnotateInterceptorAs(ProvideTraceID),
+				xgrpc.AnnotateInterceptorAs(ProvideIncomingLog),
+				xgrpc.AnnotateInterceptorAs(ProvideRecovery),
+				xgrpc.AnnotateInterceptorAs(ProvideAuth),
+			),
+		),
+	)
+)
@@ -0,0 +1,66 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/infra/http/middleware"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgrpc"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xsecurity"
+)
+
+var (
+	// publicMethods are served without authentication, they are prefix of grpc full method name.
+	// Reflection is not public, since it serves the schema of every service.
+	publicMethods = []string{
+		"/grpc.health.v1.Health/",
+	}
+)
+
+func ProvideAuth(auth *middleware.PrivateAuthJWT) Auth {
+	return Auth{auth}
+}
+
+// Auth authenticates 'authorization' metadata with the same rule of http private jwt auth.
+type Auth struct {
+	auth *middleware.PrivateAuthJWT
+}
+
+func (Auth) Name() string {
+	return "auth"
+}
+
+func (a Auth) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a Auth) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, xgrpc.WrapServerStream(ss, ctx))
+}
+
+// authenticate stores the verified principal into the context, see xsecurity.PrincipalFrom.
+func (a Auth) authenticate(ctx context.Context, method string) (context.Context, error) {
+	for _, prefix := range publicMethods {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	principal, ok := a.auth.Authenticate(xgrpc.Header(ctx, "authorization"))
+	if !ok {
+		return ctx, status.Error(codes.Unauthenticated, "invalid or missing bearer token")
+	}
+	return xsecurity.WithPrincipal(ctx, principal), nil
+}
@@ -0,0 +1,36 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgraceful"
+)
+
+func ProvideGraceful(tracker *xgraceful.Tracker) Graceful {
+	return Graceful{tracker}
+}
+
+// Graceful must be the outermost interceptor, so every in-flight call is tracked until it is completed.
+type Graceful struct {
+	tracker *xgraceful.Tracker
+}
+
+func (Graceful) Name() string {
+	return "graceful"
+}
+
+func (g Graceful) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	done := g.tracker.Track()
+	defer done()
+
+	return handler(ctx, req)
+}
+
+func (g Graceful) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	done := g.tracker.Track()
+	defer done()
+
+	return handler(srv, ss)
+}
@@ -0,0 +1,148 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgraceful"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtracer"
+)
+
+func ProvideIncomingLog(tracer trace.Tracer, debugLog *xlog.DebugLogger, tracker *xgraceful.Tracker) IncomingLog {
+	return IncomingLog{
+		tracer:   tracer,
+		debugLog: xlog.NewLogger(debugLog.Logger),
+		tracker:  tracker,
+	}
+}
+
+type IncomingLog struct {
+	tracer   trace.Tracer
+	debugLog xlog.Logger
+	tracker  *xgraceful.Tracker
+}
+
+type incomingLogData struct {
+	TraceID   string
+	Method    string
+	Peer      string
+	Header    metadata.MD
+	IsStream  bool
+	ReqBody   []byte
+	ResBody   []byte
+	Status    *status.Status
+	TimeStart time.Time
+	TimeEnd   time.Time
+}
+
+func (IncomingLog) Name() string {
+	return "incoming.log"
+}
+
+func (in IncomingLog) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, span := xtracer.Start(in.tracer, ctx, "incoming log")
+
+	var (
+		d         = in.newData(ctx, info.FullMethod, false)
+		resp, err = handler(ctx, req)
+	)
+
+	d.TimeEnd = time.Now()
+	d.Status = status.Convert(err)
+	d.ReqBody = in.marshal(req)
+	d.ResBody = in.marshal(resp)
+
+	in.tracker.Go(func() {
+		defer span.End()
+		in.log(ctx, d)
+	})
+
+	return resp, err
+}
+
+func (in IncomingLog) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	var (
+		ctx, span = xtracer.Start(in.tracer, ss.Context(), "incoming log")
+		d         = in.newData(ctx, info.FullMethod, true)
+		err       = handler(srv, ss)
+	)
+
+	d.TimeEnd = time.Now()
+	d.Status = status.Convert(err)
+
+	in.tracker.Go(func() {
+		defer span.End()
+		in.log(ctx, d)
+	})
+
+	return err
+}
+
+func (in IncomingLog) newData(ctx context.Context, method string, isStream bool) incomingLogData {
+	var (
+		tid, _ = ctx.Value(xlog.XLOG_REQ_TRACE_ID_CTX_KEY).(string)
+		md, _  = metadata.FromIncomingContext(ctx)
+		d      = incomingLogData{
+			TraceID:   tid,
+			Method:    method,
+			Header:    md,
+			IsStream:  isStream,
+			TimeStart: time.Now(),
+		}
+	)
+
+	if p, ok := peer.FromContext(ctx); ok {
+		d.Peer = p.Addr.String()
+	}
+
+	return d
+}
+
+func (IncomingLog) marshal(v any) []byte {
+	msg, ok := v.(proto.Message)
+	if !ok || msg == nil {
+		return nil
+	}
+
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func (in IncomingLog) log(ctx context.Context, d incomingLogData) {
+	fields := []any{
+		"reqTraceId", d.TraceID,
+		"reqStartTime", d.TimeStart.Format(time.RFC3339Nano),
+		"reqEndTime", d.TimeEnd.Format(time.RFC3339Nano),
+		"reqIp", d.Peer,
+		"reqHeader", d.Header,
+		"reqProto", "grpc",
+		"reqMethod", d.Method,
+		"reqStream", d.IsStream,
+		"resStatus", d.Status.Code().String(),
+		"resLatency", d.TimeEnd.Sub(d.TimeStart).String(),
+	}
+
+	if len(d.ReqBody) > 0 {
+		fields = append(fields, "reqRawBody", d.ReqBody)
+	}
+	if len(d.ResBody) > 0 {
+		fields = append(fields, "resBody", d.ResBody)
+	}
+	if d.Status.Message() != "" {
+		fields = append(fields, "resMessage", d.Status.Message())
+	}
+
+	in.debugLog.Info(ctx, "incoming log request", fields...)
+}
@@ -0,0 +1,90 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgrpc"
+)
+
+func ProvideMetrics(meter metric.Meter) (Metrics, error) {
+	var m Metrics
+
+	requests, err := meter.Int64Counter(
+		"rpc.server.request.count",
+		metric.WithDescription("Number of handled call per service, method and status"),
+	)
+	if err != nil {
+		return m, err
+	}
+
+	failures, err := meter.Int64Counter(
+		"rpc.server.request.errors",
+		metric.WithDescription("Number of failed call (server error status) per service, method and status"),
+	)
+	if err != nil {
+		return m, err
+	}
+
+	duration, err := meter.Float64Histogram(
+		"rpc.server.request.duration",
+		metric.WithDescription("Duration of handled call per service, method and status"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10),
+	)
+	if err != nil {
+		return m, err
+	}
+
+	m.requests, m.failures, m.duration = requests, failures, duration
+	return m, nil
+}
+
+// Metrics records RED (rate, errors, duration) metrics, it shares the meter with http metrics,
+// so it is exported through the same otel collector or prometheus '/metrics' endpoint.
+type Metrics struct {
+	requests metric.Int64Counter
+	failures metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func (Metrics) Name() string {
+	return "metrics"
+}
+
+func (m Metrics) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.record(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+func (m Metrics) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	m.record(ss.Context(), info.FullMethod, start, err)
+	return err
+}
+
+func (m Metrics) record(ctx context.Context, fullMethod string, start time.Time, err error) {
+	var (
+		code            = status.Code(err)
+		service, method = xgrpc.SplitMethod(fullMethod)
+		attrs           = metric.WithAttributes(
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", method),
+			attribute.String("rpc.grpc.status_code", code.String()),
+		)
+	)
+
+	m.requests.Add(ctx, 1, attrs)
+	m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+	if xgrpc.IsServerError(code) {
+		m.failures.Add(ctx, 1, attrs)
+	}
+}
@@ -0,0 +1,73 @@
+package interceptor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgrpc"
+)
+
+func ProvideOtel(tracer trace.Tracer) Otel {
+	return Otel{tracer}
+}
+
+// Otel starts server span which continues the trace context of the caller (w3c 'traceparent' metadata).
+type Otel struct {
+	tracer trace.Tracer
+}
+
+func (Otel) Name() string {
+	return "otel.grpc"
+}
+
+func (o Otel) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, span := o.start(ctx, info.FullMethod)
+	defer span.End()
+
+	resp, err := handler(ctx, req)
+	o.end(span, err)
+
+	return resp, err
+}
+
+func (o Otel) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := o.start(ss.Context(), info.FullMethod)
+	defer span.End()
+
+	err := handler(srv, xgrpc.WrapServerStream(ss, ctx))
+	o.end(span, err)
+
+	return err
+}
+
+func (o Otel) start(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, xgrpc.MetadataCarrier(md))
+
+	service, method := xgrpc.SplitMethod(fullMethod)
+	return o.tracer.Start(ctx, service+"/"+method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", method),
+		),
+	)
+}
+
+func (o Otel) end(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+
+	if xgrpc.IsServerError(code) {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, code.String())
+	}
+}
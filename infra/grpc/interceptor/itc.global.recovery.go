@@ -0,0 +1,90 @@
+package interceptor
+
+import (
+	"context"
+	"runtime/debug"
+
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgraceful"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpanic"
+)
+
+type RecoveryParam struct {
+	fx.In
+
+	Cfg      config.Cfg
+	DebugLog *xlog.DebugLogger
+	Tracker  *xgraceful.Tracker
+	Reporter xpanic.Reporter `optional:"true"`
+}
+
+func ProvideRecovery(p RecoveryParam) Recovery {
+	return Recovery{
+		cfg:      p.Cfg,
+		debugLog: xlog.NewLogger(p.DebugLog.Logger),
+		tracker:  p.Tracker,
+		reporter: p.Reporter,
+	}
+}
+
+// Recovery turns panic into 'Internal' status, the panic message and stack are only logged
+// and the panic is reported as crash report when 'crash.report' config is enabled.
+type Recovery struct {
+	cfg      config.Cfg
+	debugLog xlog.Logger
+	tracker  *xgraceful.Tracker
+	reporter xpanic.Reporter
+}
+
+func (Recovery) Name() string {
+	return "recovery"
+}
+
+func (r Recovery) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = r.recover(ctx, info.FullMethod, v, debug.Stack())
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+func (r Recovery) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = r.recover(ss.Context(), info.FullMethod, v, debug.Stack())
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+func (r Recovery) recover(ctx context.Context, method string, v any, stack []byte) error {
+	var (
+		tid, _ = ctx.Value(xlog.XLOG_REQ_TRACE_ID_CTX_KEY).(string)
+		report = xpanic.NewReport(v, stack)
+	)
+
+	report.Method = "GRPC"
+	report.Route = method
+	report.TraceID = tid
+
+	r.debugLog.Error(ctx, "grpc call is panic", "reqMethod", method, "panicMsg", report.Message, "panicFingerprint", report.Fingerprint, "panicStack", report.Stack)
+
+	if r.cfg.Crash.Report && r.reporter != nil {
+		r.tracker.Go(func() {
+			if err := r.reporter.Report(context.WithoutCancel(ctx), report); err != nil {
+				r.debugLog.Error(ctx, "failed to report panic", "panicFingerprint", report.Fingerprint, "err", err)
+			}
+		})
+	}
+
+	return status.Error(codes.Internal, "panic error")
+}
@@ -0,0 +1,59 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgrpc"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtracer"
+)
+
+func ProvideTraceID(tracer trace.Tracer) TraceID {
+	return TraceID{tracer}
+}
+
+// TraceID resolves request trace id from incoming 'x-request-id' or 'traceparent' metadata, otherwise it is generated,
+// the id is sent back in 'x-request-id' response header.
+type TraceID struct {
+	tracer trace.Tracer
+}
+
+func (TraceID) Name() string {
+	return "trace.id"
+}
+
+func (t TraceID) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, span, id := t.start(ctx)
+	defer span.End()
+
+	grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(xtracer.HeaderRequestID), id))
+
+	return handler(ctx, req)
+}
+
+func (t TraceID) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span, id := t.start(ss.Context())
+	defer span.End()
+
+	ss.SetHeader(metadata.Pairs(strings.ToLower(xtracer.HeaderRequestID), id))
+
+	return handler(srv, xgrpc.WrapServerStream(ss, ctx))
+}
+
+func (t TraceID) start(ctx context.Context) (context.Context, trace.Span, string) {
+	var (
+		md, _   = metadata.FromIncomingContext(ctx)
+		carrier = xgrpc.MetadataCarrier(md)
+		id      = xtracer.RequestID(carrier, true)
+	)
+
+	ctx = xtracer.Extract(context.WithValue(ctx, xlog.XLOG_REQ_TRACE_ID_CTX_KEY, id), carrier)
+	ctx, span := xtracer.Start(t.tracer, ctx, "global trace id")
+
+	return ctx, span, id
+}
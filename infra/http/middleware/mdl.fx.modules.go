@@ -7,26 +7,41 @@ import (
 )
 
 var (
+	// GlobalOrders is the default order of global middleware chain, it is overridden by 'middleware.global.<name>.order' config.
 	GlobalOrders = map[string]int{
-		"otel.http":    1,
-		"trace.id":     2,
-		"helmet":       3,
-		"cors":         4,
-		"incoming.log": 5,
-		"cache":        6,
-		"compress":     7,
-		"monitor":      8,
-		"favicon":      9,
+		"recovery":          1,
+		"graceful":          2,
+		"metrics":           3,
+		"otel.http":         4,
+		"trace.id":          5,
+		"i18n":              6,
+		"tenant":            7,
+		"rate.limit":        8,
+		"concurrency.limit": 9,
+		"helmet":            10,
+		"cors":              11,
+		"incoming.log":      12,
+		"cache":             13,
+		"compress":          14,
+		"monitor":           15,
+		"favicon":           16,
 	}
 
 	GlobalModules = fx.Options(
 		fx.Module("http:server:global:middleware",
 			fx.Provide(
+				xhuma.AnnotateGlobalMiddlewareAs(ProvideGraceful),
+				xhuma.AnnotateGlobalMiddlewareAs(ProvideMetrics),
 				xhuma.AnnotateGlobalMiddlewareAs(ProvideOtel),
 				xhuma.AnnotateGlobalMiddlewareAs(ProvideTraceID),
+				xhuma.AnnotateGlobalMiddlewareAs(ProvideI18n),
+				xhuma.AnnotateGlobalMiddlewareAs(ProvideTenant),
+				xhuma.AnnotateGlobalMiddlewareAs(ProvideRateLimit),
+				xhuma.AnnotateGlobalMiddlewareAs(ProvideConcurrencyLimit),
 				xhuma.AnnotateGlobalMiddlewareAs(ProvideHelmet),
 				xhuma.AnnotateGlobalMiddlewareAs(ProvideCORS),
 				xhuma.AnnotateGlobalMiddlewareAs(ProvideIncomingLog),
+				xhuma.AnnotateGlobalMiddlewareAs(ProvideRecovery),
 				xhuma.AnnotateGlobalMiddlewareAs(ProvideCache),
 				xhuma.AnnotateGlobalMiddlewareAs(ProvideCompress),
 				xhuma.AnnotateGlobalMiddlewareAs(ProvideMonitor),
@@ -37,9 +52,44 @@ var (
 )
 
 var (
+	// OperationOrders is the order of named operation middlewares, which are declared by 'xhuma.MetadataMiddlewares'
+	// or listed by 'middleware.operation.defaults' config. Audit runs before auth, so the actor is set into its scope,
+	// and idempotency runs after auth, so a stored response is only replayed to the same verified principal.
+	OperationOrders = map[string]int{
+		"timeout":     1,
+		"version":     2,
+		"log.body":    3,
+		"audit":       4,
+		"rate.limit":  5,
+		"auth":        6,
+		"auth.stream": 7,
+		"idempotency": 8,
+	}
+
+	// OperationDefaults is applied to every operation when 'middleware.operation.defaults' config is empty.
+	OperationDefaults = []string{"timeout", "version", "log.body", "audit"}
+
+	OperationModules = fx.Options(
+		fx.Module("http:server:operation:middleware",
+			fx.Provide(
+				xhuma.AnnotateOperationMiddlewareAs(NewOperationTimeout),
+				xhuma.AnnotateOperationMiddlewareAs(NewOperationVersion),
+				xhuma.AnnotateOperationMiddlewareAs(NewOperationLogBody),
+				xhuma.AnnotateOperationMiddlewareAs(NewOperationAudit),
+				xhuma.AnnotateOperationMiddlewareAs(ProvideOperationRateLimit),
+				xhuma.AnnotateOperationMiddlewareAs(ProvideOperationIdempotency),
+			),
+			fx.Provide(NewOperationRegistry),
+		),
+	)
+
 	PrivateModules = fx.Options(
 		fx.Module("http:server:private:middleware",
 			fx.Provide(NewPrivateAuthJWT),
+			fx.Provide(
+				xhuma.AnnotateOperationMiddlewareAs(ProvideOperationAuth),
+				xhuma.AnnotateOperationMiddlewareAs(ProvideOperationAuthStream),
+			),
 		),
 	)
 )
@@ -16,8 +16,8 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
-	"github.com/Mind2Screen-Dev-Team/thousand-sunny/constant"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfiber"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xi18n"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xsecurity"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtracer"
@@ -41,12 +41,12 @@ func (Cache) Name() string {
 func (Cache) App(app *fiber.App) {}
 
 func (s Cache) Serve(c *fiber.Ctx) error {
-	if next, ok := xfiber.SkipPath(c, constant.FiberSkipablePathFromMiddleware[:]...); ok {
+	if next, ok := xfiber.SkipPath(c, GlobalSkipPaths(s.cfg.Middleware)...); ok {
 		return next()
 	}
 
 	var (
-		rawReqBody = c.BodyRaw()
+		rawReqBody = xfiber.BufferedBody(c)
 		reqBody    = make([]byte, len(rawReqBody))
 	)
 	copy(reqBody, rawReqBody)
@@ -55,7 +55,7 @@ func (s Cache) Serve(c *fiber.Ctx) error {
 		ctx            = c.UserContext()
 		acceptEncoding = c.Get("Accept-Encoding")
 		reqUrl, _      = url.Parse(string(c.Request().RequestURI()))
-		hexHash256     = xsecurity.HexHashSHA256(fmt.Sprintf("%s|%s|%s", c.Method(), reqUrl.String(), string(reqBody)))
+		hexHash256     = xsecurity.HexHashSHA256(fmt.Sprintf("%s|%s|%s|%s|%s", xlog.GetReqTenantID(ctx), xi18n.LocaleFrom(ctx), c.Method(), reqUrl.String(), string(reqBody)))
 		cacheType      = "plain"
 		isCompressed   = strings.Contains(acceptEncoding, "gzip") ||
 			strings.Contains(acceptEncoding, "deflate") ||
@@ -117,6 +117,11 @@ func (s Cache) Serve(c *fiber.Ctx) error {
 		return err
 	}
 
+	// streamed response (i.e: sse, file download) is never cached, reading it blocks until the stream ends
+	if c.Response().IsBodyStream() {
+		return nil
+	}
+
 	var buf bytes.Buffer
 	c.Response().BodyWriteTo(&buf)
 
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/constant"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfiber"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+)
+
+// GlobalChain returns enabled global middlewares sorted by their order, when 'names'
+// (i.e: 'server.<key>.middlewares' config) is not empty only the listed middlewares are used.
+func GlobalChain(cfg config.Middleware, mdl []xhuma.GlobalMiddleware, names []string) []xhuma.GlobalMiddleware {
+	use := make([]xhuma.GlobalMiddleware, 0, len(mdl))
+	for _, m := range mdl {
+		if cfg.Global[m.Name()].Disabled {
+			continue
+		}
+		if len(names) == 0 || slices.Contains(names, m.Name()) {
+			use = append(use, m)
+		}
+	}
+
+	sort.SliceStable(use, func(i, j int) bool {
+		return GlobalOrder(cfg, use[i].Name()) < GlobalOrder(cfg, use[j].Name())
+	})
+
+	return use
+}
+
+// GlobalOrder returns 'middleware.global.<name>.order' config, otherwise the default order of GlobalOrders.
+func GlobalOrder(cfg config.Middleware, name string) int {
+	if o := cfg.Global[name].Order; o > 0 {
+		return o
+	}
+	return GlobalOrders[name]
+}
+
+// GlobalHandler returns the middleware handler which is skipped on 'middleware.global.<name>.skip.paths' config.
+func GlobalHandler(cfg config.Middleware, m xhuma.GlobalMiddleware) fiber.Handler {
+	skip := cfg.Global[m.Name()].SkipPaths
+	if len(skip) == 0 {
+		return m.Serve
+	}
+
+	return func(c *fiber.Ctx) error {
+		if next, ok := xfiber.SkipPath(c, skip...); ok {
+			return next()
+		}
+		return m.Serve(c)
+	}
+}
+
+// GlobalSkipPaths returns 'middleware.skip.paths' config, these infrastructure paths (i.e: docs, metrics
+// and probes) are skipped by tracing, logging, limiting and caching middlewares.
+func GlobalSkipPaths(cfg config.Middleware) []string {
+	if len(cfg.SkipPaths) == 0 {
+		return constant.FiberSkipablePathFromMiddleware[:]
+	}
+	return cfg.SkipPaths
+}
+
+type globalOptions struct {
+	name string
+	opts map[string]string
+}
+
+// newGlobalOptions reads 'middleware.global.<name>.options' config.
+func newGlobalOptions(cfg config.Middleware, name string) globalOptions {
+	return globalOptions{name, cfg.Global[name].Options}
+}
+
+func (o globalOptions) String(key, def string) string {
+	if v, ok := o.opts[key]; ok {
+		return v
+	}
+	return def
+}
+
+func (o globalOptions) Int(key string, def int) (int, error) {
+	v, ok := o.opts[key]
+	if !ok || v == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid 'middleware.global.%s.options.%s' config: %w", o.name, key, err)
+	}
+	return n, nil
+}
+
+func (o globalOptions) Bool(key string, def bool) (bool, error) {
+	v, ok := o.opts[key]
+	if !ok || v == "" {
+		return def, nil
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid 'middleware.global.%s.options.%s' config: %w", o.name, key, err)
+	}
+	return b, nil
+}
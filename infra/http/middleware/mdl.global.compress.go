@@ -3,13 +3,25 @@ package middleware
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
 )
 
-func ProvideCompress() Compress {
-	return Compress{}
+func ProvideCompress(cfg config.Cfg) (Compress, error) {
+	// -1: disabled | 0: default | 1: best speed | 2: best compression
+	level, err := newGlobalOptions(cfg.Middleware, "compress").Int("level", int(compress.LevelBestSpeed))
+	if err != nil {
+		return Compress{}, err
+	}
+
+	return Compress{compress.New(compress.Config{
+		Level: compress.Level(level),
+	})}, nil
 }
 
-type Compress struct{}
+type Compress struct {
+	handler fiber.Handler
+}
 
 func (Compress) Name() string {
 	return "compress"
@@ -17,12 +29,6 @@ func (Compress) Name() string {
 
 func (Compress) App(app *fiber.App) {}
 
-func (Compress) Serve(c *fiber.Ctx) error {
-	var (
-		cfg = compress.Config{
-			Level: compress.LevelBestSpeed,
-		}
-	)
-
-	return compress.New(cfg)(c)
+func (m Compress) Serve(c *fiber.Ctx) error {
+	return m.handler(c)
 }
@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfiber"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlimiter"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+func ProvideConcurrencyLimit(cfg config.Cfg, meter metric.Meter, debugLog *xlog.DebugLogger) (ConcurrencyLimit, error) {
+	var (
+		c = cfg.Limit.Concurrency
+		l = ConcurrencyLimit{
+			cfg:      c,
+			skip:     GlobalSkipPaths(cfg.Middleware),
+			debugLog: xlog.NewLogger(debugLog.Logger),
+			state: &concurrencyLimitState{
+				matchers: make(map[*fiber.App]*xfiber.RouteMatcher),
+				limiters: make(map[string]*xlimiter.AdaptiveLimiter),
+			},
+		}
+	)
+
+	if !c.Enabled {
+		return l, nil
+	}
+
+	shed, err := meter.Int64Counter(
+		"http.server.concurrency.shed",
+		metric.WithDescription("Number of request shed by adaptive concurrency limiter"),
+	)
+	if err != nil {
+		return l, err
+	}
+	l.state.shed = shed
+
+	limit, err := meter.Int64ObservableGauge(
+		"http.server.concurrency.limit",
+		metric.WithDescription("Current adaptive concurrency limit per route"),
+	)
+	if err != nil {
+		return l, err
+	}
+
+	inflight, err := meter.Int64ObservableGauge(
+		"http.server.concurrency.inflight",
+		metric.WithDescription("Current in-flight request per route"),
+	)
+	if err != nil {
+		return l, err
+	}
+
+	latency, err := meter.Float64ObservableGauge(
+		"http.server.concurrency.latency",
+		metric.WithDescription("Moving average of request latency per route"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		return l, err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		l.state.mu.RLock()
+		defer l.state.mu.RUnlock()
+
+		for route, lim := range l.state.limiters {
+			var (
+				snap  = lim.Snapshot()
+				attrs = metric.WithAttributes(attribute.String("http.route", route))
+			)
+			o.ObserveInt64(limit, int64(snap.Limit), attrs)
+			o.ObserveInt64(inflight, int64(snap.Inflight), attrs)
+			o.ObserveFloat64(latency, float64(snap.Latency)/float64(time.Millisecond), attrs)
+		}
+		return nil
+	}, limit, inflight, latency)
+
+	return l, err
+}
+
+type ConcurrencyLimit struct {
+	cfg      config.ConcurrencyLimit
+	skip     []string
+	debugLog xlog.Logger
+	state    *concurrencyLimitState
+}
+
+type concurrencyLimitState struct {
+	mu       sync.RWMutex
+	matchers map[*fiber.App]*xfiber.RouteMatcher
+	limiters map[string]*xlimiter.AdaptiveLimiter
+	shed     metric.Int64Counter
+}
+
+func (ConcurrencyLimit) Name() string {
+	return "concurrency.limit"
+}
+
+func (l ConcurrencyLimit) App(app *fiber.App) {
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+
+	l.state.matchers[app] = xfiber.NewRouteMatcher(app)
+}
+
+func (l ConcurrencyLimit) Serve(c *fiber.Ctx) error {
+	if !l.cfg.Enabled {
+		return c.Next()
+	}
+
+	if next, ok := xfiber.SkipPath(c, l.skip...); ok {
+		return next()
+	}
+
+	if next, ok := xfiber.SkipPath(c, l.cfg.Exempt...); ok {
+		return next()
+	}
+
+	l.state.mu.RLock()
+	matcher := l.state.matchers[c.App()]
+	l.state.mu.RUnlock()
+
+	route, ok := matcher.Match(c.Method(), c.Path())
+	if !ok {
+		// unknown route will be answered by 404 handler, nothing to protect
+		return c.Next()
+	}
+
+	var (
+		key        = c.Method() + " " + route
+		ctx        = c.UserContext()
+		lim        = l.limiter(key)
+		release, _ = lim.Acquire()
+	)
+
+	if release == nil {
+		if l.state.shed != nil {
+			l.state.shed.Add(ctx, 1, metric.WithAttributes(attribute.String("http.route", key)))
+		}
+
+		var (
+			code  = http.StatusServiceUnavailable
+			retry = max(l.cfg.RetryAfter, 1)
+			resp  = xerror.ErrServerOverloaded.
+				Newf("too many concurrent requests, retry after %d seconds", retry).
+				Response(ctx)
+		)
+
+		l.debugLog.Warn(ctx, "request is shed by concurrency limiter", "route", key, "limit", lim.Snapshot().Limit)
+
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retry))
+		return c.Status(code).JSON(resp)
+	}
+
+	// the returned error and panic are only turned into status by the outer handlers, so they are counted as drops,
+	// otherwise the failed request is recorded as success and the limit keeps growing while downstream fails
+	now := time.Now()
+	defer func() {
+		if v := recover(); v != nil {
+			release(time.Since(now), true)
+			panic(v)
+		}
+	}()
+
+	err := c.Next()
+	status := c.Response().StatusCode()
+	release(time.Since(now), err != nil || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout)
+
+	return err
+}
+
+func (l ConcurrencyLimit) limiter(key string) *xlimiter.AdaptiveLimiter {
+	l.state.mu.RLock()
+	lim, ok := l.state.limiters[key]
+	l.state.mu.RUnlock()
+
+	if ok {
+		return lim
+	}
+
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+
+	if lim, ok := l.state.limiters[key]; ok {
+		return lim
+	}
+
+	lim = xlimiter.NewAdaptiveLimiter(xlimiter.AdaptiveConfig{
+		InitialLimit:     l.cfg.Initial,
+		MinLimit:         l.cfg.Min,
+		MaxLimit:         l.cfg.Max,
+		LatencyThreshold: time.Duration(l.cfg.LatencyThreshold) * time.Millisecond,
+		BackoffRatio:     l.cfg.BackoffRatio,
+	})
+	l.state.limiters[key] = lim
+
+	return lim
+}
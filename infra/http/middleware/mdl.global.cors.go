@@ -1,15 +1,45 @@
 package middleware
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
 )
 
-func ProvideCORS() CORS {
-	return CORS{}
+func ProvideCORS(cfg config.Cfg) (CORS, error) {
+	var (
+		opts = newGlobalOptions(cfg.Middleware, "cors")
+		c    = cors.Config{
+			AllowOrigins:  opts.String("allow.origins", cors.ConfigDefault.AllowOrigins),
+			AllowMethods:  opts.String("allow.methods", cors.ConfigDefault.AllowMethods),
+			AllowHeaders:  opts.String("allow.headers", ""),
+			ExposeHeaders: opts.String("expose.headers", ""),
+		}
+		err error
+	)
+
+	if c.AllowCredentials, err = opts.Bool("allow.credentials", false); err != nil {
+		return CORS{}, err
+	}
+	if c.MaxAge, err = opts.Int("max.age", 0); err != nil {
+		return CORS{}, err
+	}
+
+	// cors.New panics on this insecure combination, so it is reported as startup error instead
+	if c.AllowCredentials && strings.TrimSpace(c.AllowOrigins) == "*" {
+		return CORS{}, errors.New("invalid 'middleware.global.cors.options' config: 'allow.credentials' can not be used with wildcard 'allow.origins'")
+	}
+
+	return CORS{cors.New(c)}, nil
 }
 
-type CORS struct{}
+type CORS struct {
+	handler fiber.Handler
+}
 
 func (CORS) Name() string {
 	return "cors"
@@ -17,6 +47,6 @@ func (CORS) Name() string {
 
 func (CORS) App(app *fiber.App) {}
 
-func (CORS) Serve(c *fiber.Ctx) error {
-	return cors.New()(c)
+func (m CORS) Serve(c *fiber.Ctx) error {
+	return m.handler(c)
 }
@@ -1,26 +1,47 @@
 package middleware
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/favicon"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
 )
 
-func ProvideFavicon() Favicon {
-	return Favicon{}
+func ProvideFavicon(cfg config.Cfg) (Favicon, error) {
+	var (
+		opts = newGlobalOptions(cfg.Middleware, "favicon")
+		c    = favicon.Config{
+			File:         opts.String("file", "storage/assets/favicon.ico"),
+			URL:          opts.String("url", "/favicon.ico"),
+			CacheControl: opts.String("cache.control", "public, max-age=31536000"),
+		}
+	)
+
+	if cfg.Middleware.Global["favicon"].Disabled {
+		return Favicon{}, nil
+	}
+
+	// favicon.New panics when the file can not be read
+	if _, err := os.Stat(c.File); err != nil {
+		return Favicon{}, fmt.Errorf("invalid 'middleware.global.favicon.options.file' config: %w", err)
+	}
+
+	return Favicon{favicon.New(c)}, nil
 }
 
-type Favicon struct{}
+type Favicon struct {
+	handler fiber.Handler
+}
 
 func (Favicon) Name() string {
-	return "monitor"
+	return "favicon"
 }
 
 func (Favicon) App(app *fiber.App) {}
 
-func (Favicon) Serve(c *fiber.Ctx) error {
-	return favicon.New(favicon.Config{
-		File:         "storage/assets/favicon.ico",
-		URL:          "/favicon.ico",
-		CacheControl: "public, max-age=31536000",
-	})(c)
+func (m Favicon) Serve(c *fiber.Ctx) error {
+	return m.handler(c)
 }
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgraceful"
+)
+
+func ProvideGraceful(tracker *xgraceful.Tracker) Graceful {
+	return Graceful{tracker}
+}
+
+// Graceful must be the outermost middleware, so every in-flight request
+// including its async logging is tracked until the response is completed.
+type Graceful struct {
+	tracker *xgraceful.Tracker
+}
+
+func (Graceful) Name() string {
+	return "graceful"
+}
+
+func (Graceful) App(app *fiber.App) {}
+
+func (g Graceful) Serve(c *fiber.Ctx) error {
+	done := g.tracker.Track()
+	defer done()
+
+	return c.Next()
+}
@@ -3,13 +3,43 @@ package middleware
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/helmet"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
 )
 
-func ProvideHelmet() Helmet {
-	return Helmet{}
+func ProvideHelmet(cfg config.Cfg) (Helmet, error) {
+	var (
+		opts = newGlobalOptions(cfg.Middleware, "helmet")
+		c    = helmet.Config{
+			ContentSecurityPolicy:     opts.String("content.security.policy", ""),
+			XFrameOptions:             opts.String("x.frame.options", ""),
+			ReferrerPolicy:            opts.String("referrer.policy", ""),
+			PermissionPolicy:          opts.String("permission.policy", ""),
+			CrossOriginResourcePolicy: opts.String("cross.origin.resource.policy", ""),
+		}
+		err error
+	)
+
+	if c.CSPReportOnly, err = opts.Bool("csp.report.only", false); err != nil {
+		return Helmet{}, err
+	}
+	if c.HSTSMaxAge, err = opts.Int("hsts.max.age", 0); err != nil {
+		return Helmet{}, err
+	}
+	if c.HSTSExcludeSubdomains, err = opts.Bool("hsts.exclude.subdomains", false); err != nil {
+		return Helmet{}, err
+	}
+	if c.HSTSPreloadEnabled, err = opts.Bool("hsts.preload", false); err != nil {
+		return Helmet{}, err
+	}
+
+	// empty value falls back into helmet default
+	return Helmet{helmet.New(c)}, nil
 }
 
-type Helmet struct{}
+type Helmet struct {
+	handler fiber.Handler
+}
 
 func (Helmet) Name() string {
 	return "helmet"
@@ -17,6 +47,6 @@ func (Helmet) Name() string {
 
 func (Helmet) App(app *fiber.App) {}
 
-func (Helmet) Serve(c *fiber.Ctx) error {
-	return helmet.New()(c)
+func (m Helmet) Serve(c *fiber.Ctx) error {
+	return m.handler(c)
 }
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xi18n"
+)
+
+func ProvideI18n(bundle *xi18n.Bundle) I18n {
+	return I18n{bundle}
+}
+
+// I18n negotiates the locale from 'Accept-Language' header, so error response and validation
+// message are localized by the next middlewares and handlers (xi18n.LocaleFrom).
+type I18n struct {
+	bundle *xi18n.Bundle
+}
+
+func (I18n) Name() string {
+	return "i18n"
+}
+
+func (I18n) App(app *fiber.App) {}
+
+func (m I18n) Serve(c *fiber.Ctx) error {
+	locale := m.bundle.Match(c.Get(fiber.HeaderAcceptLanguage))
+
+	c.SetUserContext(xi18n.WithLocale(c.UserContext(), locale))
+	c.Set(fiber.HeaderContentLanguage, locale)
+	c.Vary(fiber.HeaderAcceptLanguage)
+
+	return c.Next()
+}
@@ -1,35 +1,42 @@
 package middleware
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"maps"
+	"math/rand/v2"
+	"mime/multipart"
 	"net/http"
 	"runtime/debug"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gofiber/fiber/v2"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/constant"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfiber"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgraceful"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
-	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpanic"
-	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtracer"
 )
 
-func ProvideIncomingLog(cfg config.Cfg, tracer trace.Tracer, debugLog *xlog.DebugLogger) IncomingLog {
+// DefaultLogBodyMarker is appended into truncated body when 'log.body.marker' config is empty.
+const DefaultLogBodyMarker = "...[truncated]"
+
+func ProvideIncomingLog(cfg config.Cfg, tracer trace.Tracer, debugLog *xlog.DebugLogger, tracker *xgraceful.Tracker) IncomingLog {
 	return IncomingLog{
 		cfg:      cfg,
 		tracer:   tracer,
 		debugLog: xlog.NewLogger(debugLog.Logger),
+		redactor: debugLog.Redactor,
+		tracker:  tracker,
 	}
 }
 
@@ -37,6 +44,8 @@ type IncomingLog struct {
 	cfg      config.Cfg
 	tracer   trace.Tracer
 	debugLog xlog.Logger
+	redactor *xlog.Redactor
+	tracker  *xgraceful.Tracker
 }
 
 func (IncomingLog) Name() string {
@@ -46,7 +55,7 @@ func (IncomingLog) Name() string {
 func (IncomingLog) App(app *fiber.App) {}
 
 func (in IncomingLog) Serve(c *fiber.Ctx) error {
-	if next, ok := xfiber.SkipPath(c, constant.FiberSkipablePathFromMiddleware[:]...); ok {
+	if next, ok := xfiber.SkipPath(c, GlobalSkipPaths(in.cfg.Middleware)...); ok {
 		return next()
 	}
 
@@ -60,7 +69,7 @@ func (in IncomingLog) Serve(c *fiber.Ctx) error {
 		isCompress                      = in.isCompress(ae)
 		isMultipart, isMultipartEncoded = in.isMultipart(ct)
 
-		rawReqBody = c.BodyRaw()
+		rawReqBody = xfiber.BufferedBody(c)
 		reqBody    = make([]byte, len(rawReqBody))
 		reqSize, _ = io.Copy(io.Discard, strings.NewReader(string(rawReqBody)))
 	)
@@ -77,9 +86,14 @@ func (in IncomingLog) Serve(c *fiber.Ctx) error {
 			Values: make(map[string][]string),
 			Files:  make(map[string]xlog.FileInfo),
 		}
-		rawForms, err = c.MultipartForm()
 	)
-	if err == nil {
+
+	// streamed multipart body is parsed by the handler itself, parsing it here consumes the stream
+	var rawForms *multipart.Form
+	if !c.Request().IsBodyStream() {
+		rawForms, _ = c.MultipartForm()
+	}
+	if rawForms != nil {
 		maps.Copy(reqFormBody.Values, rawForms.Value)
 		reqSize += in.sizeMapSliceOfString(reqFormBody.Values)
 
@@ -131,48 +145,27 @@ func (in IncomingLog) Serve(c *fiber.Ctx) error {
 
 	wg.Add(1)
 	defer func() {
-		if r := recover(); r != nil {
-			d.IsPanic = true
-			d.PanicMsg = fmt.Sprintf("%v", r)
-
-			var (
-				s  = debug.Stack()
-				b  = bytes.NewBuffer(s)
-				ss = xpanic.ParseStack(b)
-			)
-
-			b.Reset()
-
-			json.NewEncoder(b).Encode(ss)
-			d.PanicStack = b.Bytes()
-
-			b.Reset()
-		}
-
-		if d.IsPanic {
-			var (
-				code = http.StatusInternalServerError
-				msg  = http.StatusText(code)
-				resp = xresp.GeneralResponseError{
-					Code: code,
-					Msg:  msg,
-					Err: &xresp.ErrorModel{
-						Title:  "panic error",
-						Detail: d.PanicMsg,
-					},
-					TraceID: tid,
-				}
-			)
-			c.Response().Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
-			json.NewEncoder(c.Response().BodyWriter()).Encode(resp)
-		}
-
 		var (
-			res             = c.Response()
-			code            = res.StatusCode()
-			buffBytesRes, _ = c.Response().BodyUncompressed()
+			res          = c.Response()
+			code         = res.StatusCode()
+			buffBytesRes []byte
 		)
 
+		// recovery is the outer middleware, so the panic is captured here and propagated into recovery,
+		// the partially written response is dropped by recovery, so it is logged as panic response.
+		if v := recover(); v != nil {
+			report := CapturePanic(c, v, debug.Stack())
+			d.IsPanic = true
+			d.PanicMsg = report.Message
+			d.PanicStack, _ = json.Marshal(report.Stack)
+			code = xerror.ErrPanic.Status
+
+			defer panic(v)
+		} else if !res.IsBodyStream() {
+			// streamed response (i.e: sse, file download) is not logged, reading it blocks until the stream ends
+			buffBytesRes, _ = res.BodyUncompressed()
+		}
+
 		for key, value := range res.Header.All() {
 			if _, ok := d.ResHeader[string(key)]; !ok {
 				d.ResHeader[string(key)] = make([]string, 0)
@@ -183,27 +176,30 @@ func (in IncomingLog) Serve(c *fiber.Ctx) error {
 		d.ResStatus = code
 		d.ResBody = buffBytesRes
 		d.ResSize = int64(len(buffBytesRes))
+		d.ResErrCode = in.errCode(code, buffBytesRes)
 
 		d.TimeStart = now
 		d.TimeEnd = time.Now().Add(time.Since(now))
 
+		d.SkipBodyReason = in.skipBody(c, code, time.Since(now))
 		d.IsHideRes, _ = ctx.Value(xlog.XLOG_HIDE_RES_FLAG_CTX_KEY).(bool)
+		d.Timeout, d.IsTimeout = c.Locals(constant.FiberLocalsOperationTimeout).(time.Duration)
 		d.ReqTraceID = tid
 
 		d.IsCompressed = isCompress
 		d.IsMultipart = isMultipart
 		d.IsMultipartEncoded = isMultipartEncoded
 
-		go func() {
+		in.tracker.Go(func() {
 			defer wg.Done()
 			in.log(ctx, d)
-		}()
+		})
 
 		// Wait for logging and end span in a blocking goroutine
-		go func() {
+		in.tracker.Go(func() {
 			wg.Wait()
 			span.End()
-		}()
+		})
 	}()
 
 	c.SetUserContext(ctx)
@@ -211,6 +207,24 @@ func (in IncomingLog) Serve(c *fiber.Ctx) error {
 	return c.Next()
 }
 
+// errCode reads 'err.code' of error response, so the log is searchable by the error catalog code.
+func (IncomingLog) errCode(status int, body []byte) string {
+	if status < http.StatusBadRequest || len(body) == 0 {
+		return ""
+	}
+
+	var res struct {
+		Err *struct {
+			Code string `json:"code"`
+		} `json:"err"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil || res.Err == nil {
+		return ""
+	}
+
+	return res.Err.Code
+}
+
 func (IncomingLog) sizeMapSliceOfString(m map[string][]string) int64 {
 	var n int64
 	for _, v := range m {
@@ -228,6 +242,8 @@ func (IncomingLog) isMultipart(ct string) (isMultipart, isMultipartEncoded bool)
 }
 
 func (in IncomingLog) log(ctx context.Context, d xlog.IncomingLogData) {
+	d = in.redact(d)
+
 	var (
 		fields = []any{
 			"reqTraceId", d.ReqTraceID,
@@ -248,27 +264,120 @@ func (in IncomingLog) log(ctx context.Context, d xlog.IncomingLogData) {
 		}
 	)
 
+	if d.SkipBodyReason != "" {
+		fields = append(fields, "bodySkipped", d.SkipBodyReason)
+	} else {
+		fields = append(fields, in.bodyFields(d)...)
+	}
+
+	if d.ResErrCode != "" {
+		fields = append(fields, "errCode", d.ResErrCode)
+	}
+
+	if d.IsTimeout {
+		fields = append(fields, "reqTimeout", d.Timeout.String())
+	}
+
+	if d.IsPanic {
+		fields = append(fields, "panicMsg", d.PanicMsg)
+		fields = append(fields, "panicStack", d.PanicStack)
+	}
+
+	in.debugLog.Info(ctx, "incoming log request", fields...)
+}
+
+func (in IncomingLog) bodyFields(d xlog.IncomingLogData) []any {
+	var fields []any
 	if d.IsMultipart || d.IsMultipartEncoded {
 		fields = append(fields, "reqFormBody", d.ReqFormBody)
 	} else if len(d.ReqBody) > 0 {
-		fields = append(fields, "reqRawBody", d.ReqBody)
+		fields = append(fields, "reqRawBody", in.truncate(d.ReqBody))
 	}
 
 	var isPrintableRes bool
 	for _, v := range d.ResHeader["Content-Type"] {
-		if strings.Contains(v, "application/json") || strings.Contains(v, "text/plain") {
+		if strings.Contains(v, "json") || strings.Contains(v, "text/plain") {
 			isPrintableRes = true
 		}
 	}
 
 	if !d.IsHideRes && isPrintableRes {
-		fields = append(fields, "resBody", d.ResBody)
+		fields = append(fields, "resBody", in.truncate(d.ResBody))
 	}
 
-	if d.IsPanic {
-		fields = append(fields, "panicMsg", d.PanicMsg)
-		fields = append(fields, "panicStack", d.PanicStack)
+	return fields
+}
+
+// skipBody returns the reason why the body is not logged, otherwise empty string. The declared 'xhuma.MetadataLogBody'
+// operation metadata wins, then error and slow request body is always logged and lastly successful request is sampled
+// by 'log.body.sampling' config.
+func (in IncomingLog) skipBody(c *fiber.Ctx, status int, latency time.Duration) string {
+	if v, ok := c.Locals(constant.FiberLocalsLogBody).(bool); ok {
+		if v {
+			return ""
+		}
+		return "operation"
 	}
 
-	in.debugLog.Info(ctx, "incoming log request", fields...)
+	s := in.cfg.Log.Body.Sampling
+	if !s.Enabled || status >= http.StatusBadRequest {
+		return ""
+	}
+
+	if s.SlowThreshold > 0 && latency >= time.Duration(s.SlowThreshold)*time.Millisecond {
+		return ""
+	}
+
+	if rand.Float64()*100 < in.sampleRate(c.Method(), c.Path()) {
+		return ""
+	}
+
+	return "sampling"
+}
+
+// sampleRate resolves the percentage of logged body, the most specific 'log.body.sampling.routes' config wins over 'log.body.sampling.rate' config.
+func (in IncomingLog) sampleRate(method, path string) float64 {
+	s := in.cfg.Log.Body.Sampling
+	if _, rate, ok := xfiber.MatchOperation(s.Routes, method, path); ok {
+		return rate
+	}
+
+	return s.Rate
+}
+
+// truncate cuts the body into 'log.body.max.size' config on utf-8 boundary and appends the marker.
+func (in IncomingLog) truncate(b []byte) []byte {
+	size := in.cfg.Log.Body.MaxSize
+	if size <= 0 || len(b) <= size {
+		return b
+	}
+
+	for size > 0 && !utf8.RuneStart(b[size]) {
+		size--
+	}
+
+	marker := in.cfg.Log.Body.Marker
+	if marker == "" {
+		marker = DefaultLogBodyMarker
+	}
+
+	out := make([]byte, 0, size+len(marker))
+	out = append(out, b[:size]...)
+	return append(out, marker...)
+}
+
+// redact masks PII of the request and response based on 'log.redact' config before it is logged.
+func (in IncomingLog) redact(d xlog.IncomingLogData) xlog.IncomingLogData {
+	if !in.redactor.Enabled() {
+		return d
+	}
+
+	d.ReqURI = []byte(in.redactor.URI(string(d.ReqURI)))
+	d.ReqHeader = in.redactor.Header(d.ReqHeader)
+	d.ReqBody = in.redactor.JSON(d.ReqBody)
+	d.ReqFormBody.Values = in.redactor.Form(d.ReqFormBody.Values)
+	d.ResHeader = in.redactor.Header(d.ResHeader)
+	d.ResBody = in.redactor.JSON(d.ResBody)
+
+	return d
 }
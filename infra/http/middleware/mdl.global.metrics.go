@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfiber"
+)
+
+func ProvideMetrics(cfg config.Cfg, meter metric.Meter) (Metrics, error) {
+	m := Metrics{skip: GlobalSkipPaths(cfg.Middleware)}
+
+	requests, err := meter.Int64Counter(
+		"http.server.request.count",
+		metric.WithDescription("Number of handled request per route, method and status"),
+	)
+	if err != nil {
+		return m, err
+	}
+
+	failures, err := meter.Int64Counter(
+		"http.server.request.errors",
+		metric.WithDescription("Number of failed request (status 5xx) per route, method and status"),
+	)
+	if err != nil {
+		return m, err
+	}
+
+	duration, err := meter.Float64Histogram(
+		"http.server.request.duration",
+		metric.WithDescription("Duration of handled request per route, method and status"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10),
+	)
+	if err != nil {
+		return m, err
+	}
+
+	m.requests, m.failures, m.duration = requests, failures, duration
+	return m, nil
+}
+
+// Metrics records RED (rate, errors, duration) metrics, so it should be placed before any middleware which may reject request.
+// The '/metrics' endpoint is not served by this middleware, it is only served by the server which sets 'additional.metrics.endpoint'.
+type Metrics struct {
+	skip []string
+
+	requests metric.Int64Counter
+	failures metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func (Metrics) Name() string {
+	return "metrics"
+}
+
+func (Metrics) App(*fiber.App) {}
+
+func (m Metrics) Serve(c *fiber.Ctx) error {
+	if next, ok := xfiber.SkipPath(c, m.skip...); ok {
+		return next()
+	}
+
+	var (
+		start = time.Now()
+		err   error
+	)
+
+	// recovery is the outer middleware, so the panic is counted as server error before it is propagated
+	defer func() {
+		if v := recover(); v != nil {
+			CapturePanic(c, v, debug.Stack())
+			m.record(c, start, http.StatusInternalServerError)
+			panic(v)
+		}
+	}()
+
+	err = c.Next()
+
+	status := c.Response().StatusCode()
+	if err != nil {
+		var fe *fiber.Error
+		if errors.As(err, &fe) {
+			status = fe.Code
+		} else {
+			status = http.StatusInternalServerError
+		}
+	}
+
+	m.record(c, start, status)
+	return err
+}
+
+func (m Metrics) record(c *fiber.Ctx, start time.Time, status int) {
+	var (
+		ctx   = c.UserContext()
+		attrs = metric.WithAttributes(
+			// route pattern is used instead of path, to keep metric cardinality low
+			attribute.String("http.route", c.Route().Path),
+			attribute.String("http.method", c.Method()),
+			attribute.String("http.status_code", strconv.Itoa(status)),
+		)
+	)
+
+	m.requests.Add(ctx, 1, attrs)
+	m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+	if status >= http.StatusInternalServerError {
+		m.failures.Add(ctx, 1, attrs)
+	}
+}
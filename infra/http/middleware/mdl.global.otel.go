@@ -1,17 +1,25 @@
 package middleware
 
 import (
-	"github.com/Mind2Screen-Dev-Team/thousand-sunny/constant"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfiber"
 	"github.com/gofiber/contrib/otelfiber/v2"
 	"github.com/gofiber/fiber/v2"
 )
 
-func ProvideOtel() Otel {
-	return Otel{}
+func ProvideOtel(cfg config.Cfg) Otel {
+	return Otel{
+		skip: GlobalSkipPaths(cfg.Middleware),
+		handler: otelfiber.Middleware(
+			otelfiber.WithCollectClientIP(true),
+		),
+	}
 }
 
-type Otel struct{}
+type Otel struct {
+	skip    []string
+	handler fiber.Handler
+}
 
 func (Otel) Name() string {
 	return "otel.http"
@@ -20,11 +28,9 @@ func (Otel) Name() string {
 func (Otel) App(app *fiber.App) {}
 
 func (m Otel) Serve(c *fiber.Ctx) error {
-	if next, ok := xfiber.SkipPath(c, constant.FiberSkipablePathFromMiddleware[:]...); ok {
+	if next, ok := xfiber.SkipPath(c, m.skip...); ok {
 		return next()
 	}
 
-	return otelfiber.Middleware(
-		otelfiber.WithCollectClientIP(true),
-	)(c)
+	return m.handler(c)
 }
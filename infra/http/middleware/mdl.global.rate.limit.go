@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfiber"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlimiter"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xsecurity"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtracer"
+)
+
+func ProvideRateLimit(cfg config.Cfg, client *redis.Client, jwt xsecurity.JWTManager, tracer trace.Tracer, debugLog *xlog.DebugLogger) RateLimit {
+	return RateLimit{
+		cfg:      cfg,
+		limiter:  xlimiter.NewRedisLimiter(client),
+		jwt:      jwt,
+		state:    &rateLimitState{matchers: make(map[*fiber.App]*xfiber.RouteMatcher)},
+		tracer:   tracer,
+		debugLog: xlog.NewLogger(debugLog.Logger),
+	}
+}
+
+type RateLimit struct {
+	cfg      config.Cfg
+	limiter  *xlimiter.RedisLimiter
+	jwt      xsecurity.JWTManager
+	state    *rateLimitState
+	tracer   trace.Tracer
+	debugLog xlog.Logger
+}
+
+type rateLimitState struct {
+	mu       sync.RWMutex
+	matchers map[*fiber.App]*xfiber.RouteMatcher
+}
+
+func (RateLimit) Name() string {
+	return "rate.limit"
+}
+
+func (r RateLimit) App(app *fiber.App) {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	r.state.matchers[app] = xfiber.NewRouteMatcher(app)
+}
+
+func (r RateLimit) Serve(c *fiber.Ctx) error {
+	if next, ok := xfiber.SkipPath(c, GlobalSkipPaths(r.cfg.Middleware)...); ok {
+		return next()
+	}
+
+	return r.serve(c, r.route, c.Next)
+}
+
+// route returns the matched route template of the fiber app, unknown route shares one bucket, it is answered with 404 anyway.
+func (r RateLimit) route(c *fiber.Ctx) string {
+	r.state.mu.RLock()
+	matcher := r.state.matchers[c.App()]
+	r.state.mu.RUnlock()
+
+	route, _ := matcher.Match(c.Method(), c.Path())
+	return route
+}
+
+// serve limits the request by the matched rule, 'route' resolves the route template for 'route' key.
+func (r RateLimit) serve(c *fiber.Ctx, route func(c *fiber.Ctx) string, next func() error) error {
+	if !r.cfg.Limit.Rate.Enabled {
+		return next()
+	}
+
+	name, rule := r.rule(c)
+	if rule.Disabled {
+		return next()
+	}
+
+	var (
+		key = fmt.Sprintf(
+			"rate_limit:%s:rule:%s:%s:%s",
+			r.cfg.App.Env,
+			xsecurity.HexHashSHA256(name),
+			rule.KeyBy,
+			r.identity(c, rule.KeyBy, route),
+		)
+		xrule = xlimiter.Rule{
+			Algorithm: rule.Algorithm,
+			Limit:     rule.Limit,
+			Window:    time.Duration(rule.Window) * time.Second,
+		}
+	)
+
+	ctx, span := xtracer.Start(r.tracer, c.UserContext(), "global rate limit")
+	res, err := r.limiter.Allow(ctx, key, xrule)
+	span.End()
+
+	if err != nil {
+		// fail open, rate limiter must not take down the service when redis is unavailable
+		r.debugLog.Error(ctx, "failed to check rate limit", "rule", name, "err", fmt.Sprintf("%+v", err))
+		return next()
+	}
+
+	reset := strconv.Itoa(int(math.Ceil(res.Reset.Seconds())))
+	c.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	c.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	c.Set("RateLimit-Reset", reset)
+	c.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", rule.Limit, rule.Window))
+
+	if !res.Allowed {
+		var (
+			code = http.StatusTooManyRequests
+			resp = xerror.ErrRateLimitExceeded.
+				Newf("too many requests, retry after %s seconds", reset).
+				Response(ctx)
+		)
+		c.Set(fiber.HeaderRetryAfter, reset)
+		return c.Status(code).JSON(resp)
+	}
+
+	return next()
+}
+
+// rule returns the most specific matched operation override, otherwise default rule.
+// Override fields which are not set are inherited from default rule.
+func (r RateLimit) rule(c *fiber.Ctx) (string, config.RateLimitRule) {
+	cfg := r.cfg.Limit.Rate
+
+	name, found, ok := xfiber.MatchOperation(cfg.Operations, c.Method(), c.Path())
+	if !ok {
+		return "default", cfg.Default
+	}
+
+	if found.Algorithm == "" {
+		found.Algorithm = cfg.Default.Algorithm
+	}
+	if found.KeyBy == "" {
+		found.KeyBy = cfg.Default.KeyBy
+	}
+	if found.Limit <= 0 {
+		found.Limit = cfg.Default.Limit
+	}
+	if found.Window <= 0 {
+		found.Window = cfg.Default.Window
+	}
+
+	return name, found
+}
+
+// identity returns client identity based on 'key.by' config, fallback into client ip.
+// The 'user' claim is only read from verified token, so a forged token is not able to get a fresh bucket,
+// and the 'route' key is the route template, so path params do not create a bucket per value.
+func (r RateLimit) identity(c *fiber.Ctx, keyBy string, route func(c *fiber.Ctx) string) string {
+	cfg := r.cfg.Limit.Rate
+
+	switch keyBy {
+	case "api.key":
+		h := cfg.APIKeyHeader
+		if h == "" {
+			h = "X-API-Key"
+		}
+		if v := c.Get(h); v != "" {
+			return xsecurity.HexHashSHA256(v)
+		}
+	case "user":
+		claim := cfg.UserClaim
+		if claim == "" {
+			claim = "sub"
+		}
+		if token, ok := xfiber.BearerToken(c); ok {
+			if claims, err := r.jwt.ValidateToken(token); err == nil {
+				if v, ok := claims[claim].(string); ok && v != "" {
+					return xsecurity.HexHashSHA256(v)
+				}
+			}
+		}
+	case "route":
+		return xsecurity.HexHashSHA256(c.Method() + " " + route(c))
+	}
+
+	return c.IP()
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/constant"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xgraceful"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpanic"
+)
+
+type RecoveryParam struct {
+	fx.In
+
+	Cfg      config.Cfg
+	DebugLog *xlog.DebugLogger
+	Tracker  *xgraceful.Tracker
+	Reporter xpanic.Reporter `optional:"true"`
+}
+
+func ProvideRecovery(p RecoveryParam) Recovery {
+	return Recovery{
+		cfg:      p.Cfg,
+		debugLog: xlog.NewLogger(p.DebugLog.Logger),
+		tracker:  p.Tracker,
+		reporter: p.Reporter,
+	}
+}
+
+// Recovery turns panic into clean 500 response, it is the outermost middleware, so a panic of any middleware is recovered.
+// The inner middleware which must observe the panic (i.e: incoming log) captures it through CapturePanic and propagates it,
+// then recovery reads the captured report (constant.FiberLocalsPanic), so the original stack is kept.
+type Recovery struct {
+	cfg      config.Cfg
+	debugLog xlog.Logger
+	tracker  *xgraceful.Tracker
+	reporter xpanic.Reporter
+}
+
+func (Recovery) Name() string {
+	return "recovery"
+}
+
+func (Recovery) App(app *fiber.App) {}
+
+func (r Recovery) Serve(c *fiber.Ctx) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = r.recover(c, v, debug.Stack())
+		}
+	}()
+
+	return c.Next()
+}
+
+func (r Recovery) recover(c *fiber.Ctx, v any, stack []byte) error {
+	var (
+		ctx    = c.UserContext()
+		tid, _ = ctx.Value(xlog.XLOG_REQ_TRACE_ID_CTX_KEY).(string)
+		report = CapturePanic(c, v, stack)
+	)
+
+	report.Method = c.Method()
+	report.Route = c.Route().Path
+	report.TraceID = tid
+
+	r.debugLog.Error(ctx, "http request is panic",
+		"reqTraceId", tid,
+		"reqMethod", report.Method,
+		"reqRoute", report.Route,
+		"panicMsg", report.Message,
+		"panicFingerprint", report.Fingerprint,
+	)
+
+	if r.cfg.Crash.Report && r.reporter != nil {
+		r.tracker.Go(func() {
+			if err := r.reporter.Report(context.WithoutCancel(ctx), report); err != nil {
+				r.debugLog.Error(ctx, "failed to report panic", "panicFingerprint", report.Fingerprint, "err", err)
+			}
+		})
+	}
+
+	// drop partially written response of the handler
+	c.Response().ResetBody()
+	c.Response().Header.Del(fiber.HeaderContentEncoding)
+
+	err := xerror.ErrPanic.New("the request can not be completed, it is reported with fingerprint " + report.Fingerprint)
+	return c.Status(err.Status()).JSON(err.Response(ctx))
+}
+
+// CapturePanic returns the report of the propagating panic, it is built once by the innermost caller and stored into
+// constant.FiberLocalsPanic, so the outer caller and recovery read the original stack. The caller must recover the panic
+// in its own deferred function and panic again after it is observed.
+func CapturePanic(c *fiber.Ctx, v any, stack []byte) xpanic.Report {
+	if report, ok := c.Locals(constant.FiberLocalsPanic).(xpanic.Report); ok {
+		return report
+	}
+
+	report := xpanic.NewReport(v, stack)
+	c.Locals(constant.FiberLocalsPanic, report)
+
+	return report
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfiber"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xsecurity"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtenant"
+)
+
+func ProvideTenant(cfg config.Cfg, jwt xsecurity.JWTManager) Tenant {
+	return Tenant{cfg.Tenant, jwt, GlobalSkipPaths(cfg.Middleware)}
+}
+
+// Tenant resolves tenant id from the configured sources, a verified access token is bound to its tenant claim,
+// so the request which resolves the other tenant (i.e: from header) is rejected.
+type Tenant struct {
+	cfg  config.Tenant
+	jwt  xsecurity.JWTManager
+	skip []string
+}
+
+func (Tenant) Name() string {
+	return "tenant"
+}
+
+func (Tenant) App(app *fiber.App) {}
+
+func (t Tenant) Serve(c *fiber.Ctx) error {
+	if !t.cfg.Enabled {
+		return c.Next()
+	}
+
+	if next, ok := xfiber.SkipPath(c, t.skip...); ok {
+		return next()
+	}
+
+	var (
+		ctx   = c.UserContext()
+		claim = t.fromJWT(c)
+		id    = t.resolve(c, claim)
+	)
+
+	if id == "" {
+		if !t.cfg.Required {
+			return c.Next()
+		}
+
+		var (
+			code = http.StatusBadRequest
+			resp = xerror.ErrTenantRequired.
+				New("unable to resolve tenant from request").
+				Response(ctx)
+		)
+		return c.Status(code).JSON(resp)
+	}
+
+	if claim != "" && claim != id {
+		var (
+			code = http.StatusForbidden
+			resp = xerror.ErrTenantMismatch.
+				New("tenant of the request does not match tenant of the access token").
+				Response(ctx)
+		)
+		return c.Status(code).JSON(resp)
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("reqTenantId", id))
+	c.SetUserContext(xtenant.WithTenant(ctx, id))
+
+	return c.Next()
+}
+
+func (t Tenant) resolve(c *fiber.Ctx, claim string) string {
+	for _, src := range t.cfg.Sources {
+		var id string
+		switch src {
+		case "header":
+			id = t.fromHeader(c)
+		case "subdomain":
+			id = t.fromSubdomain(c)
+		case "jwt":
+			id = claim
+		}
+
+		if id = strings.TrimSpace(id); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func (t Tenant) fromHeader(c *fiber.Ctx) string {
+	h := t.cfg.Header
+	if h == "" {
+		h = "X-Tenant-ID"
+	}
+	return c.Get(h)
+}
+
+func (t Tenant) fromSubdomain(c *fiber.Ctx) string {
+	var (
+		host   = strings.ToLower(c.Hostname())
+		suffix = "." + strings.ToLower(strings.TrimPrefix(t.cfg.Domain, "."))
+	)
+
+	if t.cfg.Domain == "" || !strings.HasSuffix(host, suffix) {
+		return ""
+	}
+
+	sub := strings.TrimSuffix(host, suffix)
+	if strings.Contains(sub, ".") {
+		return ""
+	}
+	return sub
+}
+
+// fromJWT reads the claim of verified access token, invalid token is ignored since it is rejected by the auth middleware.
+func (t Tenant) fromJWT(c *fiber.Ctx) string {
+	token, ok := xfiber.BearerToken(c)
+	if !ok {
+		return ""
+	}
+
+	claims, err := t.jwt.ValidateToken(token)
+	if err != nil {
+		return ""
+	}
+
+	key := t.cfg.Claim
+	if key == "" {
+		key = "tenant_id"
+	}
+
+	id, _ := claims[key].(string)
+	return id
+}
@@ -3,23 +3,36 @@ package middleware
 import (
 	"context"
 
-	"github.com/Mind2Screen-Dev-Team/thousand-sunny/constant"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfiber"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtracer"
 	"github.com/gofiber/fiber/v2"
 
-	"github.com/rs/xid"
-
 	"go.opentelemetry.io/otel/trace"
 )
 
-func ProvideTraceID(tracer trace.Tracer) TraceID {
-	return TraceID{tracer}
+func ProvideTraceID(cfg config.Cfg, tracer trace.Tracer) (TraceID, error) {
+	opts := newGlobalOptions(cfg.Middleware, "trace.id")
+
+	incoming, err := opts.Bool("incoming", true)
+	if err != nil {
+		return TraceID{}, err
+	}
+
+	return TraceID{
+		tracer:   tracer,
+		skip:     GlobalSkipPaths(cfg.Middleware),
+		incoming: incoming,
+	}, nil
 }
 
+// TraceID resolves request trace id from incoming request id header or W3C traceparent, otherwise it is generated.
+// The id is echoed in the response header, so it is available on non JSON and middleware error responses as well.
 type TraceID struct {
-	tracer trace.Tracer
+	tracer   trace.Tracer
+	skip     []string
+	incoming bool
 }
 
 func (TraceID) App(app *fiber.App) {}
@@ -29,20 +42,27 @@ func (TraceID) Name() string {
 }
 
 func (t TraceID) Serve(c *fiber.Ctx) error {
-	if next, ok := xfiber.SkipPath(c, constant.FiberSkipablePathFromMiddleware[:]...); ok {
+	if next, ok := xfiber.SkipPath(c, t.skip...); ok {
 		return next()
 	}
 
 	var (
-		ctx  = c.UserContext()
-		id   = xid.New()
-		span trace.Span
+		carrier = xfiber.HeaderCarrier{C: c}
+		id      = xtracer.RequestID(carrier, t.incoming)
+		ctx     = context.WithValue(c.UserContext(), xlog.XLOG_REQ_TRACE_ID_CTX_KEY, id)
+		span    trace.Span
 	)
 
+	// the span is a child of incoming traceparent even when otel middleware is disabled
+	if t.incoming {
+		ctx = xtracer.Extract(ctx, carrier)
+	}
+
 	ctx, span = xtracer.Start(t.tracer, ctx, "global trace id")
 	defer span.End()
 
-	c.SetUserContext(context.WithValue(ctx, xlog.XLOG_REQ_TRACE_ID_CTX_KEY, id.String()))
+	c.Set(xtracer.HeaderRequestID, id)
+	c.SetUserContext(ctx)
 
 	return c.Next()
 }
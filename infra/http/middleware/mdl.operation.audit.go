@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xaudit"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type (
+	OperationAuditParams struct {
+		fx.In
+
+		Cfg      config.Cfg
+		Debug    *xlog.DebugLogger
+		Recorder xaudit.Recorder `optional:"true"`
+	}
+)
+
+func NewOperationAudit(p OperationAuditParams) *OperationAudit {
+	return &OperationAudit{
+		enabled:  p.Cfg.Audit.Enabled && p.Recorder != nil,
+		recorder: p.Recorder,
+		log:      xlog.NewLogger(p.Debug.Logger),
+	}
+}
+
+// OperationAudit is huma middleware, it records audit entry of every successful mutating operation. The actor is set by
+// auth middleware and the before and after state is set by the service through 'xaudit.SetChange', it can be switched off
+// by declared 'xhuma.MetadataAudit' operation metadata.
+type OperationAudit struct {
+	enabled  bool
+	recorder xaudit.Recorder
+	log      xlog.Logger
+}
+
+func (*OperationAudit) Name() string {
+	return "audit"
+}
+
+func (a *OperationAudit) Serve(c huma.Context, next func(c huma.Context)) {
+	if !a.enabled || !a.audited(c) {
+		next(c)
+		return
+	}
+
+	var (
+		scope = &xaudit.Scope{}
+		ctx   = xaudit.WithScope(c.Context(), scope)
+	)
+
+	next(huma.WithContext(c, ctx))
+
+	if c.Status() >= http.StatusBadRequest {
+		return
+	}
+
+	var (
+		op = c.Operation()
+		fc = humafiber.Unwrap(c)
+	)
+
+	entry, ok, err := scope.Entry(xhuma.OperationAuditResource(op), c.Param("id"))
+	if err != nil {
+		a.log.Error(ctx, "failed to build audit entry", "operationId", op.OperationID, "err", err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	entry.Action = op.OperationID
+	if entry.Action == "" {
+		entry.Action = fmt.Sprintf("%s %s", op.Method, op.Path)
+	}
+
+	entry.TenantID = xlog.GetReqTenantID(ctx)
+	entry.TraceID = xlog.GetReqTraceID(ctx)
+	entry.IP = fc.IP()
+	entry.UserAgent = c.Header("User-Agent")
+
+	// the handler is completed, so the operation deadline no longer applies, the writer bounds the wait itself
+	if err := a.recorder.Record(context.WithoutCancel(ctx), entry); err != nil {
+		a.log.Error(ctx, "failed to record audit entry", "auditEntry", entry, "err", err)
+	}
+}
+
+func (a *OperationAudit) audited(c huma.Context) bool {
+	if v, ok := xhuma.OperationAudit(c.Operation()); ok {
+		return v
+	}
+
+	switch c.Method() {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,261 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xredis"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xsecurity"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtracer"
+)
+
+// ProvideOperationIdempotency is 'idempotency' operation middleware, it only applies to the operation which declares it.
+// It runs after 'auth', the stored response is scoped by the verified principal, so the request without principal
+// is served without idempotency.
+func ProvideOperationIdempotency(cfg config.Cfg, client *redis.Client, locker *redislock.Client, tracer trace.Tracer, debugLog *xlog.DebugLogger) *xhuma.NamedOperationMiddleware {
+	s := Idempotency{
+		cfg:      cfg,
+		client:   client,
+		locker:   locker,
+		tracer:   tracer,
+		debugLog: xlog.NewLogger(debugLog.Logger),
+	}
+
+	return xhuma.NewOperationMiddleware("idempotency", func(c huma.Context, next func(huma.Context)) {
+		principal, ok := xsecurity.PrincipalFrom(c.Context())
+		if !ok || principal.Subject == "" {
+			next(c)
+			return
+		}
+
+		serveOperation(c, next, func(fc *fiber.Ctx, next func() error) error {
+			return s.serve(fc, principal.Subject, next)
+		})
+	})
+}
+
+type Idempotency struct {
+	cfg      config.Cfg
+	client   *redis.Client
+	locker   *redislock.Client
+	tracer   trace.Tracer
+	debugLog xlog.Logger
+}
+
+// idempotencyRecord is the stored final response of the first request.
+type idempotencyRecord struct {
+	Fingerprint string              `json:"fingerprint"`
+	Status      int                 `json:"status"`
+	Headers     map[string][]string `json:"headers"`
+	Body        []byte              `json:"body"`
+}
+
+// serve replays the stored response of the same caller, idempotency key and payload, otherwise it stores the final response.
+// The caller is the verified principal, so the stored response is never replayed to the other caller.
+func (s Idempotency) serve(c *fiber.Ctx, caller string, next func() error) error {
+	var (
+		cfg     = s.cfg.Idempotency
+		header  = cfg.Header
+		methods = cfg.Methods
+	)
+
+	if !cfg.Enabled {
+		return next()
+	}
+
+	if header == "" {
+		header = "Idempotency-Key"
+	}
+	if len(methods) == 0 {
+		methods = []string{fiber.MethodPost, fiber.MethodPut}
+	}
+
+	idemKey := strings.TrimSpace(c.Get(header))
+	if idemKey == "" || !slices.Contains(methods, c.Method()) {
+		return next()
+	}
+
+	body, ok := s.body(c)
+	if !ok {
+		// the rest of streamed body is not read, so the connection can not be reused
+		c.Context().SetConnectionClose()
+		return s.reject(c, xerror.ErrRequestTooLarge.Newf("request body with idempotency key must not exceed %d bytes", s.bodyLimit()))
+	}
+
+	var (
+		ctx         = c.UserContext()
+		fingerprint = xsecurity.HexHashSHA256(fmt.Sprintf("%s|%s|%s|%s", caller, c.Method(), c.OriginalURL(), string(body)))
+		storeKey    = fmt.Sprintf(
+			"idempotency:%s:tenant:%s:caller:%s:key:%s",
+			s.cfg.App.Env,
+			xlog.GetReqTenantID(ctx),
+			xsecurity.HexHashSHA256(caller),
+			xsecurity.HexHashSHA256(idemKey),
+		)
+		lockKey = storeKey + ":lock"
+		ttl     = time.Duration(max(cfg.TTL, 1)) * time.Second
+		lockTTL = time.Duration(max(cfg.LockTTL, 1)) * time.Second
+	)
+
+	ctx, span := xtracer.Start(s.tracer, ctx, "operation idempotency")
+	defer span.End()
+
+	if rec, ok := s.get(c, storeKey); ok {
+		return s.replay(c, rec, fingerprint)
+	}
+
+	lock, err := s.locker.Obtain(ctx, lockKey, lockTTL, nil)
+	if errors.Is(err, redislock.ErrNotObtained) {
+		return s.reject(c, xerror.ErrIdempotencyInProgress.New("a request with the same idempotency key is still in progress"))
+	}
+	if err != nil {
+		// fail open, idempotency must not take down the service when redis is unavailable
+		s.debugLog.Error(ctx, "failed to obtain idempotency lock", "err", fmt.Sprintf("%+v", err))
+		return next()
+	}
+	defer lock.Release(ctx)
+
+	// the first request may have been completed while we are waiting for the lock
+	if rec, ok := s.get(c, storeKey); ok {
+		return s.replay(c, rec, fingerprint)
+	}
+
+	// the lock is refreshed while the handler is running, so it is not expired before the response is stored
+	stop := xredis.KeepLock(ctx, lock, lockTTL, func(err error) {
+		s.debugLog.Error(ctx, "failed to extend idempotency lock", "err", fmt.Sprintf("%+v", err))
+	})
+	err = next()
+	stop()
+
+	if err != nil {
+		return err
+	}
+
+	// server error is not stored, so client can safely retry with the same key
+	res := c.Response()
+	if res.StatusCode() >= http.StatusInternalServerError {
+		return nil
+	}
+
+	var (
+		// trace headers belong to the first request, the replayed response carries its own
+		ignores = []string{
+			fiber.HeaderDate,
+			fiber.HeaderContentLength,
+			fiber.HeaderContentEncoding,
+			xtracer.HeaderRequestID,
+			xtracer.HeaderTraceParent,
+			"Tracestate",
+		}
+		rec = idempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      res.StatusCode(),
+			Headers:     make(map[string][]string),
+		}
+	)
+
+	rec.Body, _ = res.BodyUncompressed()
+	for key, value := range res.Header.All() {
+		k := string(key)
+		if !slices.ContainsFunc(ignores, func(v string) bool { return strings.EqualFold(v, k) }) && !strings.HasPrefix(k, "Ratelimit-") {
+			rec.Headers[k] = append(rec.Headers[k], string(value))
+		}
+	}
+
+	b, err := json.Marshal(rec)
+	if err != nil {
+		s.debugLog.Error(ctx, "failed to marshal idempotency record", "err", fmt.Sprintf("%+v", err))
+		return nil
+	}
+
+	if err := s.client.Set(ctx, storeKey, b, ttl).Err(); err != nil {
+		s.debugLog.Error(ctx, "failed to store idempotency record", "err", fmt.Sprintf("%+v", err))
+	}
+
+	return nil
+}
+
+// body returns request body for the fingerprint. Streamed body is read up to the body limit and put back
+// as in-memory stream, so the handler still reads it, the body which exceeds the limit is refused.
+func (s Idempotency) body(c *fiber.Ctx) ([]byte, bool) {
+	req := c.Request()
+	if !req.IsBodyStream() {
+		return c.BodyRaw(), true
+	}
+
+	limit := s.bodyLimit()
+	if n := req.Header.ContentLength(); n > limit {
+		return nil, false
+	}
+
+	b, err := io.ReadAll(io.LimitReader(req.BodyStream(), int64(limit)+1))
+	if err != nil || len(b) > limit {
+		return nil, false
+	}
+
+	req.SetBodyStream(bytes.NewReader(b), len(b))
+	return b, true
+}
+
+func (s Idempotency) bodyLimit() int {
+	if s.cfg.Idempotency.BodyLimit > 0 {
+		return s.cfg.Idempotency.BodyLimit
+	}
+	return fiber.DefaultBodyLimit
+}
+
+func (s Idempotency) get(c *fiber.Ctx, key string) (idempotencyRecord, bool) {
+	var rec idempotencyRecord
+
+	b, err := s.client.Get(c.UserContext(), key).Bytes()
+	if err != nil {
+		return rec, false
+	}
+
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return rec, false
+	}
+
+	return rec, true
+}
+
+func (s Idempotency) replay(c *fiber.Ctx, rec idempotencyRecord, fingerprint string) error {
+	if rec.Fingerprint != fingerprint {
+		return s.reject(c, xerror.ErrIdempotencyMismatch.New("idempotency key is already used with a different request payload"))
+	}
+
+	for k, vs := range rec.Headers {
+		for i, v := range vs {
+			if i == 0 {
+				c.Set(k, v)
+			} else {
+				c.Append(k, v)
+			}
+		}
+	}
+
+	c.Set("Idempotent-Replayed", "true")
+	c.Status(rec.Status)
+	return c.Send(rec.Body)
+}
+
+func (s Idempotency) reject(c *fiber.Ctx, err *xerror.Error) error {
+	return c.Status(err.Status()).JSON(err.Response(c.UserContext()))
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/constant"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+)
+
+func NewOperationLogBody() *OperationLogBody {
+	return &OperationLogBody{}
+}
+
+// OperationLogBody is huma middleware, it passes declared 'xhuma.MetadataLogBody' operation metadata
+// to incoming log (constant.FiberLocalsLogBody), so body logging is switched off per operation.
+type OperationLogBody struct{}
+
+func (*OperationLogBody) Name() string {
+	return "log.body"
+}
+
+func (*OperationLogBody) Serve(c huma.Context, next func(c huma.Context)) {
+	if v, ok := xhuma.OperationLogBody(c.Operation()); ok {
+		humafiber.Unwrap(c).Locals(constant.FiberLocalsLogBody, v)
+	}
+
+	next(c)
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xsecurity"
+)
+
+// ProvideOperationRateLimit is 'rate.limit' operation middleware, it limits only the operation which declares it,
+// so do not list 'rate.limit' global middleware on the same server, otherwise the request is counted twice.
+// The route key is the operation path template.
+func ProvideOperationRateLimit(cfg config.Cfg, client *redis.Client, jwt xsecurity.JWTManager, tracer trace.Tracer, debugLog *xlog.DebugLogger) *xhuma.NamedOperationMiddleware {
+	r := ProvideRateLimit(cfg, client, jwt, tracer, debugLog)
+	return xhuma.NewOperationMiddleware("rate.limit", func(c huma.Context, next func(huma.Context)) {
+		route := func(*fiber.Ctx) string { return c.Operation().Path }
+		serveOperation(c, next, func(fc *fiber.Ctx, next func() error) error {
+			return r.serve(fc, route, next)
+		})
+	})
+}
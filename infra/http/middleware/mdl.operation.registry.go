@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+)
+
+type OperationRegistryParam struct {
+	fx.In
+
+	Cfg         config.Cfg
+	Middlewares []xhuma.OperationMiddleware `group:"global:http:operation:middleware"`
+}
+
+func NewOperationRegistry(p OperationRegistryParam) (*OperationRegistry, error) {
+	r := &OperationRegistry{
+		defaults: p.Cfg.Middleware.Operation.Defaults,
+		mdl:      make(map[string]xhuma.OperationMiddleware, len(p.Middlewares)),
+		errs:     make(map[string]error),
+		chains:   make(map[*huma.Operation][]xhuma.OperationMiddleware),
+	}
+
+	for _, m := range p.Middlewares {
+		if _, ok := r.mdl[m.Name()]; ok {
+			return nil, fmt.Errorf("operation middleware '%s' is provided more than once", m.Name())
+		}
+		r.mdl[m.Name()] = m
+	}
+
+	if len(r.defaults) == 0 {
+		r.defaults = OperationDefaults
+	}
+	for _, name := range r.defaults {
+		if _, ok := r.mdl[name]; !ok {
+			return nil, fmt.Errorf("unknown operation middleware '%s' in 'middleware.operation.defaults' config", name)
+		}
+	}
+
+	return r, nil
+}
+
+// OperationRegistry applies named operation middlewares which are listed by 'middleware.operation.defaults' config
+// and declared by 'xhuma.MetadataMiddlewares' operation metadata, they are sorted by OperationOrders regardless of the declared order.
+type OperationRegistry struct {
+	defaults []string
+	mdl      map[string]xhuma.OperationMiddleware
+
+	mu     sync.RWMutex
+	errs   map[string]error
+	chains map[*huma.Operation][]xhuma.OperationMiddleware
+}
+
+// Use applies the registry into every operation which is registered through the group.
+func (r *OperationRegistry) Use(g *huma.Group) {
+	g.UseModifier(r.validate)
+	g.UseMiddleware(r.Serve)
+}
+
+// Err reports operations which reference unknown middleware, it must be checked after every handler is registered.
+func (r *OperationRegistry) Err() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]string, 0, len(r.errs))
+	for k := range r.errs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	errs := make([]error, 0, len(keys))
+	for _, k := range keys {
+		errs = append(errs, r.errs[k])
+	}
+	return errors.Join(errs...)
+}
+
+func (r *OperationRegistry) Serve(c huma.Context, next func(huma.Context)) {
+	r.run(r.chain(c.Operation()), c, next)
+}
+
+func (r *OperationRegistry) run(chain []xhuma.OperationMiddleware, c huma.Context, next func(huma.Context)) {
+	if len(chain) == 0 {
+		next(c)
+		return
+	}
+
+	chain[0].Serve(c, func(c huma.Context) {
+		r.run(chain[1:], c, next)
+	})
+}
+
+// chain resolves operation middlewares once, then it is reused by the next requests.
+func (r *OperationRegistry) chain(op *huma.Operation) []xhuma.OperationMiddleware {
+	r.mu.RLock()
+	chain, ok := r.chains[op]
+	r.mu.RUnlock()
+	if ok {
+		return chain
+	}
+
+	names := append(slices.Clone(r.defaults), xhuma.OperationMiddlewares(op)...)
+	chain = make([]xhuma.OperationMiddleware, 0, len(names))
+	for _, name := range names {
+		if m, ok := r.mdl[name]; ok && !slices.Contains(chain, m) {
+			chain = append(chain, m)
+		}
+	}
+
+	sort.SliceStable(chain, func(i, j int) bool {
+		return OperationOrders[chain[i].Name()] < OperationOrders[chain[j].Name()]
+	})
+
+	r.mu.Lock()
+	r.chains[op] = chain
+	r.mu.Unlock()
+
+	return chain
+}
+
+func (r *OperationRegistry) validate(op *huma.Operation, next func(*huma.Operation)) {
+	for _, name := range xhuma.OperationMiddlewares(op) {
+		if _, ok := r.mdl[name]; ok {
+			continue
+		}
+
+		key := op.Method + " " + op.Path + " " + name
+
+		r.mu.Lock()
+		r.errs[key] = fmt.Errorf("operation '%s' (%s %s) references unknown middleware '%s'", op.OperationID, op.Method, op.Path, name)
+		r.mu.Unlock()
+	}
+
+	next(op)
+}
+
+// serveOperation runs fiber middleware as huma middleware, the error is written by fiber error handler
+// since the operation is already inside the fiber handler.
+func serveOperation(c huma.Context, next func(huma.Context), serve func(fc *fiber.Ctx, next func() error) error) {
+	fc := humafiber.Unwrap(c)
+	err := serve(fc, func() error {
+		next(c)
+		return nil
+	})
+	if err != nil {
+		_ = fc.App().Config().ErrorHandler(fc, err)
+	}
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/constant"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfiber"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+)
+
+func NewOperationTimeout(cfg config.Cfg) *OperationTimeout {
+	return &OperationTimeout{cfg: cfg.Timeout}
+}
+
+// OperationTimeout is huma middleware, it sets context deadline which is honored by downstream
+// pgx, redis and resty calls that use the request context. The cancellation is cooperative,
+// so the handler is not interrupted, but its failed response is replaced by 504 once the deadline is exceeded.
+type OperationTimeout struct {
+	cfg config.Timeout
+}
+
+func (*OperationTimeout) Name() string {
+	return "timeout"
+}
+
+func (t *OperationTimeout) Serve(c huma.Context, next func(c huma.Context)) {
+	d := t.timeout(c.Operation())
+	if d <= 0 {
+		next(c)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), d)
+	defer cancel()
+
+	next(huma.WithContext(c, ctx))
+
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return
+	}
+
+	var (
+		fc     = humafiber.Unwrap(c)
+		code   = http.StatusGatewayTimeout
+		detail = fmt.Sprintf("operation is not completed within %s", d)
+		span   = trace.SpanFromContext(ctx)
+	)
+
+	fc.Locals(constant.FiberLocalsOperationTimeout, d)
+
+	span.SetAttributes(attribute.String("operation.timeout", d.String()))
+	span.RecordError(ctx.Err())
+	span.SetStatus(codes.Error, detail)
+
+	// keep the response of operation which is completed successfully, even after the deadline
+	if fc.Response().StatusCode() < http.StatusInternalServerError {
+		return
+	}
+
+	fc.Response().ResetBody()
+	fc.Status(code).JSON(xerror.ErrOperationTimeout.New(detail).Response(ctx))
+}
+
+// timeout resolves operation timeout, the precedence is the most specific 'timeout.operations' config,
+// then declared 'xhuma.MetadataTimeout' operation metadata and lastly 'timeout.default' config.
+func (t *OperationTimeout) timeout(op *huma.Operation) time.Duration {
+	if _, secs, ok := xfiber.MatchOperation(t.cfg.Operations, op.Method, op.Path); ok {
+		return time.Duration(secs) * time.Second
+	}
+
+	if d, ok := xhuma.OperationTimeout(op); ok {
+		return d
+	}
+
+	return time.Duration(t.cfg.Default) * time.Second
+}
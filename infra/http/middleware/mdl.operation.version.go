@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"github.com/danielgtaylor/huma/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+)
+
+func NewOperationVersion(meter metric.Meter) (*OperationVersion, error) {
+	requests, err := meter.Int64Counter(
+		"http.server.api.version.request.count",
+		metric.WithDescription("Number of handled request per api version, deprecation and route, it tells when a deprecated version can be removed"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OperationVersion{requests: requests}, nil
+}
+
+// OperationVersion is huma middleware, it emits 'Deprecation', 'Sunset' and 'Link' headers of deprecated
+// operation and records the api version usage, the version and the deprecation are resolved by 'xhuma.VersionModifier'.
+type OperationVersion struct {
+	requests metric.Int64Counter
+}
+
+func (*OperationVersion) Name() string {
+	return "version"
+}
+
+func (v *OperationVersion) Serve(c huma.Context, next func(c huma.Context)) {
+	var (
+		op                      = c.Operation()
+		version                 = xhuma.OperationVersion(op)
+		deprecation, deprecated = xhuma.OperationDeprecation(op)
+	)
+
+	if deprecated {
+		for k, val := range deprecation.Header() {
+			c.SetHeader(k, val)
+		}
+	}
+
+	if version != "" {
+		v.requests.Add(c.Context(), 1, metric.WithAttributes(
+			attribute.String("api.version", version),
+			attribute.Bool("api.deprecated", deprecated),
+			attribute.String("http.request.method", op.Method),
+			attribute.String("http.route", op.Path),
+		))
+	}
+
+	next(c)
+}
@@ -7,11 +7,15 @@ import (
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/gofiber/fiber/v2"
 	"go.uber.org/fx"
 
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xaudit"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
-	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xsecurity"
 )
 
 type (
@@ -20,11 +24,13 @@ type (
 
 		Cfg   config.Cfg
 		Debug *xlog.DebugLogger
+		JWT   xsecurity.JWTManager
 	}
 
 	PrivateAuthJWT struct {
 		cfg   config.Cfg
 		debug xlog.Logger
+		jwt   xsecurity.JWTManager
 	}
 )
 
@@ -33,29 +39,57 @@ func NewPrivateAuthJWT(p PrivateAuthJWTParams) (*PrivateAuthJWT, error) {
 		return nil, errors.New("field 'Debug' with type '*xlog.DebugLogger' is not provided")
 	}
 
-	return &PrivateAuthJWT{cfg: p.Cfg, debug: xlog.NewLogger(p.Debug.Logger)}, nil
+	return &PrivateAuthJWT{cfg: p.Cfg, debug: xlog.NewLogger(p.Debug.Logger), jwt: p.JWT}, nil
+}
+
+// ProvideOperationAuth is 'auth' operation middleware.
+func ProvideOperationAuth(a *PrivateAuthJWT) *xhuma.NamedOperationMiddleware {
+	return xhuma.NewOperationMiddleware("auth", a.Serve)
+}
+
+// ProvideOperationAuthStream is 'auth.stream' operation middleware, see PrivateAuthJWT.ServeStream.
+func ProvideOperationAuthStream(a *PrivateAuthJWT) *xhuma.NamedOperationMiddleware {
+	return xhuma.NewOperationMiddleware("auth.stream", a.ServeStream)
 }
 
 func (a PrivateAuthJWT) Serve(c huma.Context, next func(c huma.Context)) {
+	a.serve(c, next, false)
+}
+
+// ServeStream is used by sse operation, it also accepts 'access_token' query,
+// because browser EventSource api is unable to send Authorization header.
+func (a PrivateAuthJWT) ServeStream(c huma.Context, next func(c huma.Context)) {
+	a.serve(c, next, true)
+}
+
+// Fiber authenticates plain fiber route (i.e: websocket) with the same rule of ServeStream.
+func (a PrivateAuthJWT) Fiber(c *fiber.Ctx) error {
 	var (
-		ctx    = c.Context()
-		auth   = c.Header("Authorization")
-		code   = http.StatusUnauthorized
-		tid, _ = ctx.Value(xlog.XLOG_REQ_TRACE_ID_CTX_KEY).(string)
-		resp   = xresp.GeneralResponseError{
-			Code:    code,
-			Msg:     http.StatusText(code),
-			TraceID: tid,
-		}
+		ctx  = c.UserContext()
+		code = http.StatusUnauthorized
 	)
 
-	if !strings.HasPrefix(auth, "Bearer ") {
-		c.SetHeader("Content-Type", "application/json")
-		json.NewEncoder(c.BodyWriter()).Encode(resp)
-		return
+	principal, ok := a.authenticate(c.Get("Authorization"), c.Query("access_token"), true)
+	if !ok {
+		return c.Status(code).JSON(xerror.ErrInvalidToken.New("").Response(ctx))
 	}
 
-	if token := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer ")); token != "abc" {
+	a.debug.Info(ctx, "auth is success")
+
+	c.SetUserContext(xsecurity.WithPrincipal(ctx, principal))
+	return c.Next()
+}
+
+func (a PrivateAuthJWT) serve(c huma.Context, next func(c huma.Context), withQuery bool) {
+	var (
+		ctx  = c.Context()
+		code = http.StatusUnauthorized
+	)
+
+	principal, ok := a.authenticate(c.Header("Authorization"), c.Query("access_token"), withQuery)
+	if !ok {
+		resp := xerror.ErrInvalidToken.New("").Response(ctx)
+		c.SetStatus(code)
 		c.SetHeader("Content-Type", "application/json")
 		json.NewEncoder(c.BodyWriter()).Encode(resp)
 		return
@@ -63,5 +97,41 @@ func (a PrivateAuthJWT) Serve(c huma.Context, next func(c huma.Context)) {
 
 	a.debug.Info(ctx, "auth is success")
 
-	next(c)
+	// the actor is the verified principal, the scope exists only on audited operation
+	ctx = xsecurity.WithPrincipal(ctx, principal)
+	xaudit.SetActor(ctx, principal.Subject)
+
+	next(huma.WithContext(c, ctx))
+}
+
+// Authenticate verifies 'Bearer <token>' authorization value, i.e: grpc 'authorization' metadata.
+func (a PrivateAuthJWT) Authenticate(auth string) (xsecurity.Principal, bool) {
+	return a.authenticate(auth, "", false)
+}
+
+// Verify validates the raw token, it is used by global middleware which runs before the operation auth.
+func (a PrivateAuthJWT) Verify(token string) (xsecurity.Principal, bool) {
+	if token == "" {
+		return xsecurity.Principal{}, false
+	}
+
+	claims, err := a.jwt.ValidateToken(token)
+	if err != nil {
+		return xsecurity.Principal{}, false
+	}
+	return xsecurity.NewPrincipal(claims), true
+}
+
+func (a PrivateAuthJWT) authenticate(auth, query string, withQuery bool) (xsecurity.Principal, bool) {
+	return a.Verify(a.token(auth, query, withQuery))
+}
+
+func (a PrivateAuthJWT) token(auth, query string, withQuery bool) string {
+	switch {
+	case strings.HasPrefix(auth, "Bearer "):
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	case withQuery:
+		return strings.TrimSpace(query)
+	}
+	return ""
 }
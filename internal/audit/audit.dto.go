@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xaudit"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfilter"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+var (
+	ErrAuditInvalidFilter = xerror.Define("AUDIT_INVALID_FILTER", http.StatusBadRequest, "audit log filter is invalid")
+)
+
+var (
+	// FilterConfigs are the filterable columns of audit logs, the key of 'filters' query is the field.
+	FilterConfigs = []xfilter.Config{
+		{Column: "actor", Label: "Actor", Field: "actor", Type: xfilter.Text, Description: "Who does the operation", Operations: xfilter.TextOperation},
+		{Column: "tenant_id", Label: "Tenant", Field: "tenantId", Type: xfilter.Text, Description: "Tenant of the operation", Operations: xfilter.TextOperation},
+		{Column: "action", Label: "Action", Field: "action", Type: xfilter.Text, Description: "Operation id", Operations: xfilter.TextOperation},
+		{Column: "resource_type", Label: "Resource Type", Field: "resourceType", Type: xfilter.Text, Description: "Type of the changed resource", Operations: xfilter.TextOperation},
+		{Column: "resource_id", Label: "Resource ID", Field: "resourceId", Type: xfilter.Text, Description: "ID of the changed resource", Operations: xfilter.TextOperation},
+		{Column: "ip", Label: "IP", Field: "ip", Type: xfilter.Text, Description: "Client ip address", Operations: xfilter.TextOperation},
+		{Column: "trace_id", Label: "Trace ID", Field: "traceId", Type: xfilter.Text, Description: "Trace id of the request", Operations: xfilter.TextOperation},
+		{Column: "occurred_at", Label: "Occurred At", Field: "occurredAt", Type: xfilter.Date, Description: "When the operation is completed", Operations: xfilter.DateOperation},
+	}
+)
+
+type (
+	AuditLogData struct {
+		ID           string                   `json:"id" doc:"Audit log id" example:"cs0h4ls6n88ja2m6c9u0"`
+		Actor        string                   `json:"actor" doc:"Subject of access token, 'anonymous' when it is unknown" example:"user-123"`
+		TenantID     string                   `json:"tenantId" doc:"Tenant of the operation" example:"acme"`
+		Action       string                   `json:"action" doc:"Operation id" example:"api-update-user"`
+		ResourceType string                   `json:"resourceType" doc:"Type of the changed resource" example:"user"`
+		ResourceID   string                   `json:"resourceId" doc:"ID of the changed resource" example:"01929b6e-8f3a-7c4e-9d2b-5a6f7e8d9c0b"`
+		Diff         map[string]xaudit.Change `json:"diff" doc:"Changed fields, 'before' is null for created field and 'after' is null for removed field"`
+		IP           string                   `json:"ip" doc:"Client ip address" example:"10.0.0.1"`
+		UserAgent    string                   `json:"userAgent" doc:"Client user agent" example:"Mozilla/5.0"`
+		TraceID      string                   `json:"traceId" doc:"Trace id of the request" example:"cs0h4ls6n88ja2m6c9ug"`
+		OccurredAt   time.Time                `json:"occurredAt" doc:"Timestamp when the operation is completed" example:"2024-07-16T15:04:05Z" format:"date-time"`
+	}
+
+	AuditLogPageData struct {
+		Items  []AuditLogData `json:"items" doc:"Audit logs sorted by the latest occurred"`
+		Total  int64          `json:"total" doc:"Total of filtered audit logs" example:"1"`
+		Limit  int            `json:"limit" doc:"Page size" example:"20"`
+		Offset int            `json:"offset" doc:"Page offset" example:"0"`
+	}
+)
+
+func NewAuditLogData(e xaudit.Entry) AuditLogData {
+	diff := e.Diff
+	if diff == nil {
+		diff = map[string]xaudit.Change{}
+	}
+
+	return AuditLogData{
+		ID:           e.ID,
+		Actor:        e.Actor,
+		TenantID:     e.TenantID,
+		Action:       e.Action,
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID,
+		Diff:         diff,
+		IP:           e.IP,
+		UserAgent:    e.UserAgent,
+		TraceID:      e.TraceID,
+		OccurredAt:   e.OccurredAt,
+	}
+}
+
+func ExampleAuditLogData() AuditLogData {
+	return AuditLogData{
+		ID:           xid.New().String(),
+		Actor:        "user-123",
+		TenantID:     "acme",
+		Action:       "api-update-user",
+		ResourceType: "user",
+		ResourceID:   "01929b6e-8f3a-7c4e-9d2b-5a6f7e8d9c0b",
+		Diff: map[string]xaudit.Change{
+			"name": {Before: "John", After: "John Doe"},
+		},
+		IP:         "10.0.0.1",
+		UserAgent:  "Mozilla/5.0",
+		TraceID:    xid.New().String(),
+		OccurredAt: time.Now(),
+	}
+}
+
+// AuditOperationResponses documents success response and the given error status codes.
+func AuditOperationResponses(ref string, example any, codes ...int) map[string]*huma.Response {
+	responses := map[string]*huma.Response{
+		strconv.Itoa(http.StatusOK): {
+			Description: "Successful response",
+			Content: map[string]*huma.MediaType{
+				"application/json": {
+					Schema: &huma.Schema{
+						Ref: "schemas/" + ref,
+					},
+					Example: example,
+				},
+			},
+		},
+	}
+
+	for _, code := range append(codes, http.StatusUnauthorized, http.StatusInternalServerError) {
+		responses[strconv.Itoa(code)] = &huma.Response{
+			Description: http.StatusText(code),
+			Content: map[string]*huma.MediaType{
+				"application/json": {
+					Schema: &huma.Schema{
+						Ref: "schemas/GeneralResponseError",
+					},
+					Example: xresp.GeneralResponseError{
+						Code:    code,
+						Msg:     http.StatusText(code),
+						TraceID: xid.New().String(),
+					},
+				},
+			},
+		}
+	}
+
+	return responses
+}
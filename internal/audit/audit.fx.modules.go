@@ -0,0 +1,20 @@
+package audit
+
+import (
+	"go.uber.org/fx"
+)
+
+var (
+	RepoModules = fx.Module("repository:module:audit",
+		fx.Provide(NewRepo),
+	)
+
+	ServiceModules = fx.Module("service:module:audit",
+		fx.Provide(NewService),
+		fx.Provide(NewRecorder),
+	)
+
+	HandlerModules = fx.Module("http:handler:module:audit",
+		fx.Provide(NewReadAllHandlerFx),
+	)
+)
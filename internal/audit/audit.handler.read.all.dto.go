@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+type (
+	AuditReadAllRequestInput struct {
+		Filters string `query:"filters" example:"{\"resourceType\":{\"type\":\"text\",\"operation\":\"is\",\"values\":[\"user\"]}}" doc:"JSON object of field and filter, the fields are actor, tenantId, action, resourceType, resourceId, ip, traceId (text) and occurredAt (date)"`
+		Limit   int    `query:"limit" default:"20" minimum:"1" maximum:"100" example:"20" doc:"Page size"`
+		Offset  int    `query:"offset" default:"0" minimum:"0" example:"0" doc:"Page offset"`
+	}
+
+	AuditReadAllResponseOutput struct {
+		Body   AuditReadAllResponseBody
+		Status int
+	}
+)
+
+type (
+	AuditReadAllResponseBody xresp.GeneralResponse[*AuditLogPageData, any]
+)
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfilter"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type AuditReadAllHandlerParamFx struct {
+	fx.In
+
+	AuditSvc AuditServiceAPI
+	LogDebug *xlog.DebugLogger
+}
+
+type AuditReadAllHandlerFx struct {
+	p      AuditReadAllHandlerParamFx
+	logger xlog.Logger
+}
+
+type AuditReadAllHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewReadAllHandlerFx(p AuditReadAllHandlerParamFx) AuditReadAllHandlerFxOut {
+	return AuditReadAllHandlerFxOut{
+		Handler: &AuditReadAllHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h AuditReadAllHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation(), h.Serve)
+}
+
+func (h AuditReadAllHandlerFx) Group() string {
+	return "admin"
+}
+
+func (h AuditReadAllHandlerFx) Operation() huma.Operation {
+	return huma.Operation{
+		OperationID:   "admin-read-all-audit-log",
+		Path:          "/admin/audit-logs",
+		Method:        http.MethodGet,
+		Summary:       "Retrieves All Audit Logs",
+		Description:   "Retrieves audit trail of mutating operations filtered by 'filters' query, the latest occurred is listed first.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Audit Logs"},
+		Metadata:      map[string]any{xhuma.MetadataMiddlewares: []string{"auth"}},
+		Responses: AuditOperationResponses("AuditReadAllResponseBody", AuditReadAllResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &AuditLogPageData{
+				Items:  []AuditLogData{ExampleAuditLogData()},
+				Total:  1,
+				Limit:  20,
+				Offset: 0,
+			},
+			TraceID: xid.New().String(),
+		}, http.StatusBadRequest),
+	}
+}
+
+func (h AuditReadAllHandlerFx) Serve(ctx context.Context, in *AuditReadAllRequestInput) (out *AuditReadAllResponseOutput, err error) {
+	var filters map[string]xfilter.Filter
+	if in.Filters != "" {
+		if err := json.Unmarshal([]byte(in.Filters), &filters); err != nil {
+			return nil, ErrAuditInvalidFilter.Wrap(err, "filters must be JSON object of field and filter")
+		}
+	}
+
+	logs, total, err := h.p.AuditSvc.ReadAll(ctx, filters, in.Limit, in.Offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to read all audit log", "input", in, "errCode", xerror.CodeOf(err), "err", fmt.Sprintf("%+v", err))
+		return nil, xerror.From(err, "failed to read all audit log")
+	}
+
+	items := make([]AuditLogData, len(logs))
+	for i, l := range logs {
+		items[i] = NewAuditLogData(l)
+	}
+
+	var (
+		body = AuditReadAllResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &AuditLogPageData{
+				Items:  items,
+				Total:  total,
+				Limit:  in.Limit,
+				Offset: in.Offset,
+			},
+		}
+
+		resp = AuditReadAllResponseOutput{
+			Status: http.StatusOK,
+			Body:   body,
+		}
+	)
+
+	return &resp, nil
+}
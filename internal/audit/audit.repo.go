@@ -0,0 +1,176 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/postgres"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/gen/sqlc"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xaudit"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfilter"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtenant"
+)
+
+var (
+	dialect = goqu.Dialect("postgres")
+
+	// columns are ordered as 'sqlc.AuditLog' fields, so the rows are scanned by position.
+	columns = []any{"id", "actor", "tenant_id", "action", "resource_type", "resource_id", "diff", "ip", "user_agent", "trace_id", "occurred_at"}
+)
+
+type AuditRepoAPI interface {
+	xaudit.Store
+
+	ReadAll(ctx context.Context, filters map[string]xfilter.Filter, limit int, offset int) ([]xaudit.Entry, int64, error)
+}
+
+type (
+	AuditRepoParamFx struct {
+		fx.In
+
+		Cfg config.Cfg
+		DB  *pgxpool.Pool
+	}
+
+	AuditImplRepoFx struct {
+		cfg config.Cfg
+		db  *pgxpool.Pool
+		q   *sqlc.Queries
+	}
+)
+
+// NewRepo uses the postgres pool directly instead of 'sqlc.DBTX', audit logs of every tenant are in the same table.
+func NewRepo(p AuditRepoParamFx) (AuditRepoAPI, error) {
+	if p.DB == nil {
+		return nil, errors.New("field 'DB' with type '*pgxpool.Pool' is not provided")
+	}
+
+	return &AuditImplRepoFx{cfg: p.Cfg, db: p.DB, q: sqlc.New(p.DB)}, nil
+}
+
+// Insert writes the entries in one transaction, so a failed batch is never partially stored.
+func (r *AuditImplRepoFx) Insert(ctx context.Context, entries []xaudit.Entry) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.q.WithTx(tx)
+	for _, e := range entries {
+		diff, err := json.Marshal(e.Diff)
+		if err != nil {
+			return err
+		}
+
+		err = q.InsertAuditLog(ctx, sqlc.InsertAuditLogParams{
+			ID:           e.ID,
+			Actor:        e.Actor,
+			TenantID:     e.TenantID,
+			Action:       e.Action,
+			ResourceType: e.ResourceType,
+			ResourceID:   e.ResourceID,
+			Diff:         diff,
+			Ip:           e.IP,
+			UserAgent:    e.UserAgent,
+			TraceID:      e.TraceID,
+			OccurredAt:   e.OccurredAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *AuditImplRepoFx) ReadAll(ctx context.Context, filters map[string]xfilter.Filter, limit int, offset int) ([]xaudit.Entry, int64, error) {
+	var where []exp.Expression
+	for _, e := range xfilter.NewBuild(filters, FilterConfigs).ToExpression() {
+		if e != nil {
+			where = append(where, e)
+		}
+	}
+
+	// the tenant of the request is always a predicate, so one tenant never reads the other tenant logs,
+	// and the request without tenant is refused when tenancy is enabled
+	if tenant, ok := xtenant.FromContext(ctx); ok {
+		where = append(where, goqu.C("tenant_id").Eq(tenant))
+	} else if r.cfg.Tenant.Enabled {
+		return nil, 0, xerror.ErrTenantRequired.New("audit log can only be read within a tenant")
+	}
+
+	ds := dialect.From("audit_logs").Where(where...).Prepared(true)
+
+	query, args, err := ds.Select(goqu.COUNT("*")).ToSQL()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query, args, err = ds.Select(columns...).
+		Order(goqu.I("occurred_at").Desc(), goqu.I("id").Desc()).
+		Limit(uint(limit)).
+		Offset(uint(offset)).
+		ToSQL()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	logs, err := pgx.CollectRows(rows, pgx.RowToStructByPos[sqlc.AuditLog])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]xaudit.Entry, 0, len(logs))
+	for _, l := range logs {
+		e, err := newEntry(l)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, *e)
+	}
+
+	return entries, total, nil
+}
+
+func newEntry(row sqlc.AuditLog) (*xaudit.Entry, error) {
+	e := xaudit.Entry{
+		ID:           row.ID,
+		Actor:        row.Actor,
+		TenantID:     row.TenantID,
+		Action:       row.Action,
+		ResourceType: row.ResourceType,
+		ResourceID:   row.ResourceID,
+		IP:           row.Ip,
+		UserAgent:    row.UserAgent,
+		TraceID:      row.TraceID,
+		OccurredAt:   row.OccurredAt,
+	}
+
+	if len(row.Diff) > 0 {
+		if err := json.Unmarshal(row.Diff, &e.Diff); err != nil {
+			return nil, err
+		}
+	}
+
+	return &e, nil
+}
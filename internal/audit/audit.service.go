@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xaudit"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xfilter"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type AuditServiceAPI interface {
+	ReadAll(ctx context.Context, filters map[string]xfilter.Filter, limit int, offset int) ([]xaudit.Entry, int64, error)
+}
+
+type (
+	AuditServiceParamFx struct {
+		fx.In
+
+		AuditRepo AuditRepoAPI `optional:"false"`
+	}
+
+	AuditImplServiceFx struct {
+		p AuditServiceParamFx
+	}
+
+	AuditWriterParamFx struct {
+		fx.In
+
+		Lc        fx.Lifecycle
+		Cfg       config.Cfg
+		AuditRepo AuditRepoAPI `optional:"false"`
+		LogDebug  *xlog.DebugLogger
+	}
+)
+
+func NewService(p AuditServiceParamFx) (AuditServiceAPI, error) {
+	if p.AuditRepo == nil {
+		return nil, errors.New("failed to load audit repo")
+	}
+	return &AuditImplServiceFx{p}, nil
+}
+
+// NewRecorder provides asynchronous writer as 'xaudit.Recorder' for audit operation middleware, it is stopped
+// after the servers, since its hook is registered before theirs, so the buffered entries are written on shutdown.
+func NewRecorder(p AuditWriterParamFx) (xaudit.Recorder, error) {
+	if p.AuditRepo == nil {
+		return nil, errors.New("failed to load audit repo")
+	}
+
+	w := xaudit.NewWriter(p.AuditRepo, xaudit.Options{
+		BufferSize:     p.Cfg.Audit.BufferSize,
+		BatchSize:      p.Cfg.Audit.BatchSize,
+		FlushInterval:  time.Duration(p.Cfg.Audit.FlushInterval) * time.Second,
+		EnqueueTimeout: time.Duration(p.Cfg.Audit.EnqueueTimeout) * time.Second,
+	}, xlog.NewLogger(p.LogDebug.Logger))
+
+	p.Lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			w.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return w.Stop(ctx)
+		},
+	})
+
+	return w, nil
+}
+
+func (s *AuditImplServiceFx) ReadAll(ctx context.Context, filters map[string]xfilter.Filter, limit int, offset int) ([]xaudit.Entry, int64, error) {
+	for field, f := range filters {
+		if err := validateFilter(field, f); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return s.p.AuditRepo.ReadAll(ctx, filters, limit, offset)
+}
+
+// validateFilter rejects unknown field, type and operation, which are silently ignored by 'xfilter.Build'.
+func validateFilter(field string, f xfilter.Filter) error {
+	i := slices.IndexFunc(FilterConfigs, func(c xfilter.Config) bool { return c.Field == field && !c.Disabled })
+	if i < 0 {
+		return ErrAuditInvalidFilter.Newf("unknown filter field '%s'", field)
+	}
+
+	c := FilterConfigs[i]
+	if f.Type != c.Type {
+		return ErrAuditInvalidFilter.Newf("filter field '%s' must be type '%s'", field, c.Type)
+	}
+
+	if !slices.Contains(c.Operations, f.Operation) {
+		return ErrAuditInvalidFilter.Newf("filter field '%s' does not support operation '%s'", field, f.Operation)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package crash
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpanic"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+var (
+	ErrCrashReportNotFound = xerror.Define("CRASH_REPORT_NOT_FOUND", http.StatusNotFound, "crash report is not found")
+)
+
+type (
+	// CrashReport is grouped recovered panics which have the same fingerprint.
+	CrashReport struct {
+		Fingerprint    string
+		Message        string
+		TopFrames      []*xpanic.Frame
+		Stack          []xpanic.Stack
+		Method         string
+		Route          string
+		Count          int64
+		SampleTraceIDs []string
+		FirstSeenAt    time.Time
+		LastSeenAt     time.Time
+	}
+)
+
+type (
+	CrashReportFrameData struct {
+		Func string `json:"func" doc:"Function name of the frame" example:"github.com/Mind2Screen-Dev-Team/thousand-sunny/internal/user.(*ExampleUserImplServiceFx).Read"`
+		File string `json:"file" doc:"Source file of the frame" example:"/app/internal/user/user.service.go"`
+		Line int    `json:"line" doc:"Source line of the frame" example:"72"`
+	}
+
+	CrashReportData struct {
+		Fingerprint    string                 `json:"fingerprint" doc:"Hash of the top frames, the same panic site has the same fingerprint" example:"3f9a1c0d52e7b864"`
+		Message        string                 `json:"message" doc:"Latest panic message" example:"runtime error: invalid memory address or nil pointer dereference"`
+		TopFrames      []CrashReportFrameData `json:"topFrames" doc:"Top frames which are used as fingerprint"`
+		Method         string                 `json:"method" doc:"Latest request method, 'GRPC' for grpc call" example:"GET"`
+		Route          string                 `json:"route" doc:"Latest request route or grpc full method" example:"/api/v1/users/{id}"`
+		Count          int64                  `json:"count" doc:"How many times the panic is recovered" example:"12"`
+		SampleTraceIDs []string               `json:"sampleTraceIds" doc:"Latest trace ids of the panicked requests"`
+		FirstSeenAt    time.Time              `json:"firstSeenAt" doc:"Timestamp when the panic is recovered at first" example:"2024-07-16T15:04:05Z" format:"date-time"`
+		LastSeenAt     time.Time              `json:"lastSeenAt" doc:"Timestamp when the panic is recovered at last" example:"2024-07-16T16:30:00Z" format:"date-time"`
+	}
+
+	CrashReportDetailData struct {
+		CrashReportData
+		Stack []xpanic.Stack `json:"stack" doc:"Latest parsed goroutine stacks"`
+	}
+)
+
+func NewCrashReportData(r CrashReport) CrashReportData {
+	frames := make([]CrashReportFrameData, 0, len(r.TopFrames))
+	for _, f := range r.TopFrames {
+		if f == nil {
+			continue
+		}
+		frames = append(frames, CrashReportFrameData{Func: f.Func, File: f.File, Line: f.Line})
+	}
+
+	traceIDs := r.SampleTraceIDs
+	if traceIDs == nil {
+		traceIDs = []string{}
+	}
+
+	return CrashReportData{
+		Fingerprint:    r.Fingerprint,
+		Message:        r.Message,
+		TopFrames:      frames,
+		Method:         r.Method,
+		Route:          r.Route,
+		Count:          r.Count,
+		SampleTraceIDs: traceIDs,
+		FirstSeenAt:    r.FirstSeenAt,
+		LastSeenAt:     r.LastSeenAt,
+	}
+}
+
+func NewCrashReportDetailData(r CrashReport) CrashReportDetailData {
+	return CrashReportDetailData{
+		CrashReportData: NewCrashReportData(r),
+		Stack:           r.Stack,
+	}
+}
+
+func ExampleCrashReportData() CrashReportData {
+	return CrashReportData{
+		Fingerprint: "3f9a1c0d52e7b864",
+		Message:     "runtime error: invalid memory address or nil pointer dereference",
+		TopFrames: []CrashReportFrameData{
+			{
+				Func: "github.com/Mind2Screen-Dev-Team/thousand-sunny/internal/user.(*ExampleUserImplServiceFx).Read",
+				File: "/app/internal/user/user.service.go",
+				Line: 72,
+			},
+		},
+		Method:         http.MethodGet,
+		Route:          "/api/v1/users/{id}",
+		Count:          12,
+		SampleTraceIDs: []string{xid.New().String()},
+		FirstSeenAt:    time.Now().Add(-24 * time.Hour),
+		LastSeenAt:     time.Now(),
+	}
+}
+
+// CrashOperationResponses documents success response and the given error status codes.
+func CrashOperationResponses(ref string, example any, codes ...int) map[string]*huma.Response {
+	responses := map[string]*huma.Response{
+		strconv.Itoa(http.StatusOK): {
+			Description: "Successful response",
+			Content: map[string]*huma.MediaType{
+				"application/json": {
+					Schema: &huma.Schema{
+						Ref: "schemas/" + ref,
+					},
+					Example: example,
+				},
+			},
+		},
+	}
+
+	for _, code := range append(codes, http.StatusUnauthorized, http.StatusInternalServerError) {
+		responses[strconv.Itoa(code)] = &huma.Response{
+			Description: http.StatusText(code),
+			Content: map[string]*huma.MediaType{
+				"application/json": {
+					Schema: &huma.Schema{
+						Ref: "schemas/GeneralResponseError",
+					},
+					Example: xresp.GeneralResponseError{
+						Code:    code,
+						Msg:     http.StatusText(code),
+						TraceID: xid.New().String(),
+					},
+				},
+			},
+		}
+	}
+
+	return responses
+}
+
+type CrashReportPageData struct {
+	Items  []CrashReportData `json:"items" doc:"Crash reports sorted by the latest seen"`
+	Total  int64             `json:"total" doc:"Total of crash reports" example:"1"`
+	Limit  int               `json:"limit" doc:"Page size" example:"20"`
+	Offset int               `json:"offset" doc:"Page offset" example:"0"`
+}
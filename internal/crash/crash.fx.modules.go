@@ -0,0 +1,21 @@
+package crash
+
+import (
+	"go.uber.org/fx"
+)
+
+var (
+	RepoModules = fx.Module("repository:module:crash",
+		fx.Provide(NewRepo),
+	)
+
+	ServiceModules = fx.Module("service:module:crash",
+		fx.Provide(NewService),
+		fx.Provide(NewReporter),
+	)
+
+	HandlerModules = fx.Module("http:handler:module:crash",
+		fx.Provide(NewReadAllHandlerFx),
+		fx.Provide(NewReadHandlerFx),
+	)
+)
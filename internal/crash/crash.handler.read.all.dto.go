@@ -0,0 +1,21 @@
+package crash
+
+import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+type (
+	CrashReadAllRequestInput struct {
+		Limit  int `query:"limit" default:"20" minimum:"1" maximum:"100" example:"20" doc:"Page size"`
+		Offset int `query:"offset" default:"0" minimum:"0" example:"0" doc:"Page offset"`
+	}
+
+	CrashReadAllResponseOutput struct {
+		Body   CrashReadAllResponseBody
+		Status int
+	}
+)
+
+type (
+	CrashReadAllResponseBody xresp.GeneralResponse[*CrashReportPageData, any]
+)
@@ -0,0 +1,104 @@
+package crash
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type CrashReadAllHandlerParamFx struct {
+	fx.In
+
+	CrashSvc CrashServiceAPI
+	LogDebug *xlog.DebugLogger
+}
+
+type CrashReadAllHandlerFx struct {
+	p      CrashReadAllHandlerParamFx
+	logger xlog.Logger
+}
+
+type CrashReadAllHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewReadAllHandlerFx(p CrashReadAllHandlerParamFx) CrashReadAllHandlerFxOut {
+	return CrashReadAllHandlerFxOut{
+		Handler: &CrashReadAllHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h CrashReadAllHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation(), h.Serve)
+}
+
+func (h CrashReadAllHandlerFx) Group() string {
+	return "admin"
+}
+
+func (h CrashReadAllHandlerFx) Operation() huma.Operation {
+	return huma.Operation{
+		OperationID:   "admin-read-all-crash-report",
+		Path:          "/admin/crash-reports",
+		Method:        http.MethodGet,
+		Summary:       "Retrieves All Crash Reports",
+		Description:   "Retrieves recovered panics grouped by fingerprint, the latest seen is listed first.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Crash Reports"},
+		Metadata:      map[string]any{xhuma.MetadataMiddlewares: []string{"auth"}},
+		Responses: CrashOperationResponses("CrashReadAllResponseBody", CrashReadAllResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &CrashReportPageData{
+				Items:  []CrashReportData{ExampleCrashReportData()},
+				Total:  1,
+				Limit:  20,
+				Offset: 0,
+			},
+			TraceID: xid.New().String(),
+		}, http.StatusBadRequest),
+	}
+}
+
+func (h CrashReadAllHandlerFx) Serve(ctx context.Context, in *CrashReadAllRequestInput) (out *CrashReadAllResponseOutput, err error) {
+	reports, total, err := h.p.CrashSvc.ReadAll(ctx, in.Limit, in.Offset)
+	if err != nil {
+		h.logger.Error(ctx, "failed to read all crash report", "input", in, "errCode", xerror.CodeOf(err), "err", fmt.Sprintf("%+v", err))
+		return nil, xerror.From(err, "failed to read all crash report")
+	}
+
+	items := make([]CrashReportData, len(reports))
+	for i, r := range reports {
+		items[i] = NewCrashReportData(r)
+	}
+
+	var (
+		body = CrashReadAllResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &CrashReportPageData{
+				Items:  items,
+				Total:  total,
+				Limit:  in.Limit,
+				Offset: in.Offset,
+			},
+		}
+
+		resp = CrashReadAllResponseOutput{
+			Status: http.StatusOK,
+			Body:   body,
+		}
+	)
+
+	return &resp, nil
+}
@@ -0,0 +1,20 @@
+package crash
+
+import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+type (
+	CrashReadRequestInput struct {
+		Fingerprint string `path:"fingerprint" example:"3f9a1c0d52e7b864" doc:"Crash report fingerprint" required:"true"`
+	}
+
+	CrashReadResponseOutput struct {
+		Body   CrashReadResponseBody
+		Status int
+	}
+)
+
+type (
+	CrashReadResponseBody xresp.GeneralResponse[*CrashReportDetailData, any]
+)
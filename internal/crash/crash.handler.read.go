@@ -0,0 +1,92 @@
+package crash
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type CrashReadHandlerParamFx struct {
+	fx.In
+
+	CrashSvc CrashServiceAPI
+	LogDebug *xlog.DebugLogger
+}
+
+type CrashReadHandlerFx struct {
+	p      CrashReadHandlerParamFx
+	logger xlog.Logger
+}
+
+type CrashReadHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewReadHandlerFx(p CrashReadHandlerParamFx) CrashReadHandlerFxOut {
+	return CrashReadHandlerFxOut{
+		Handler: &CrashReadHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h CrashReadHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation(), h.Serve)
+}
+
+func (h CrashReadHandlerFx) Group() string {
+	return "admin"
+}
+
+func (h CrashReadHandlerFx) Operation() huma.Operation {
+	example := CrashReportDetailData{CrashReportData: ExampleCrashReportData()}
+
+	return huma.Operation{
+		OperationID:   "admin-read-crash-report",
+		Path:          "/admin/crash-reports/{fingerprint}",
+		Method:        http.MethodGet,
+		Summary:       "Retrieves Crash Report",
+		Description:   "Retrieves crash report by the given fingerprint including the latest parsed stack.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Crash Reports"},
+		Metadata:      map[string]any{xhuma.MetadataMiddlewares: []string{"auth"}},
+		Responses: CrashOperationResponses("CrashReadResponseBody", CrashReadResponseBody{
+			Code:    http.StatusOK,
+			Msg:     "ok",
+			Data:    &example,
+			TraceID: xid.New().String(),
+		}, http.StatusNotFound),
+	}
+}
+
+func (h CrashReadHandlerFx) Serve(ctx context.Context, in *CrashReadRequestInput) (out *CrashReadResponseOutput, err error) {
+	report, err := h.p.CrashSvc.Read(ctx, in.Fingerprint)
+	if err != nil {
+		h.logger.Error(ctx, "failed to read crash report", "fingerprint", in.Fingerprint, "errCode", xerror.CodeOf(err), "err", fmt.Sprintf("%+v", err))
+		return nil, xerror.From(err, "failed to read crash report")
+	}
+
+	var (
+		data = NewCrashReportDetailData(*report)
+		body = CrashReadResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &data,
+		}
+
+		resp = CrashReadResponseOutput{
+			Status: http.StatusOK,
+			Body:   body,
+		}
+	)
+
+	return &resp, nil
+}
@@ -0,0 +1,141 @@
+package crash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/gen/sqlc"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpanic"
+)
+
+type CrashRepoAPI interface {
+	Upsert(ctx context.Context, r xpanic.Report, maxSamples int) (*CrashReport, error)
+	Read(ctx context.Context, fingerprint string) (*CrashReport, error)
+	ReadAll(ctx context.Context, limit int, offset int) ([]CrashReport, int64, error)
+}
+
+type (
+	CrashRepoParamFx struct {
+		fx.In
+
+		DB *pgxpool.Pool
+	}
+
+	CrashImplRepoFx struct {
+		q *sqlc.Queries
+	}
+)
+
+// NewRepo uses the postgres pool directly instead of 'sqlc.DBTX', crash reports are not tenant scoped.
+func NewRepo(p CrashRepoParamFx) (CrashRepoAPI, error) {
+	if p.DB == nil {
+		return nil, errors.New("field 'DB' with type '*pgxpool.Pool' is not provided")
+	}
+
+	return &CrashImplRepoFx{q: sqlc.New(p.DB)}, nil
+}
+
+func (r *CrashImplRepoFx) Upsert(ctx context.Context, report xpanic.Report, maxSamples int) (*CrashReport, error) {
+	topFrames, err := json.Marshal(report.TopFrames)
+	if err != nil {
+		return nil, err
+	}
+
+	stack, err := json.Marshal(report.Stack)
+	if err != nil {
+		return nil, err
+	}
+
+	seenAt := report.Time
+	if seenAt.IsZero() {
+		seenAt = time.Now()
+	}
+
+	row, err := r.q.UpsertCrashReport(ctx, sqlc.UpsertCrashReportParams{
+		Fingerprint: report.Fingerprint,
+		Message:     report.Message,
+		TopFrames:   topFrames,
+		Stack:       stack,
+		Method:      report.Method,
+		Route:       report.Route,
+		TraceID:     report.TraceID,
+		SeenAt:      seenAt,
+		MaxSamples:  int32(maxSamples),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newCrashReport(row)
+}
+
+func (r *CrashImplRepoFx) Read(ctx context.Context, fingerprint string) (*CrashReport, error) {
+	row, err := r.q.FindCrashReportByFingerprint(ctx, fingerprint)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCrashReportNotFound.Newf("crash report with fingerprint %s is not found", fingerprint)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newCrashReport(row)
+}
+
+func (r *CrashImplRepoFx) ReadAll(ctx context.Context, limit int, offset int) ([]CrashReport, int64, error) {
+	total, err := r.q.CountCrashReports(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.q.FindCrashReports(ctx, sqlc.FindCrashReportsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reports := make([]CrashReport, 0, len(rows))
+	for _, row := range rows {
+		report, err := newCrashReport(row)
+		if err != nil {
+			return nil, 0, err
+		}
+		reports = append(reports, *report)
+	}
+
+	return reports, total, nil
+}
+
+func newCrashReport(row sqlc.CrashReport) (*CrashReport, error) {
+	r := CrashReport{
+		Fingerprint:    row.Fingerprint,
+		Message:        row.Message,
+		Method:         row.Method,
+		Route:          row.Route,
+		Count:          row.Count,
+		SampleTraceIDs: row.SampleTraceIds,
+		FirstSeenAt:    row.FirstSeenAt,
+		LastSeenAt:     row.LastSeenAt,
+	}
+
+	if len(row.TopFrames) > 0 {
+		if err := json.Unmarshal(row.TopFrames, &r.TopFrames); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(row.Stack) > 0 {
+		if err := json.Unmarshal(row.Stack, &r.Stack); err != nil {
+			return nil, err
+		}
+	}
+
+	return &r, nil
+}
@@ -0,0 +1,70 @@
+package crash
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpanic"
+)
+
+const (
+	// DefaultMaxSamples is used when 'crash.max.samples' config is not set.
+	DefaultMaxSamples = 10
+)
+
+type CrashServiceAPI interface {
+	xpanic.Reporter
+
+	Read(ctx context.Context, fingerprint string) (*CrashReport, error)
+	ReadAll(ctx context.Context, limit int, offset int) ([]CrashReport, int64, error)
+}
+
+type (
+	CrashServiceParamFx struct {
+		fx.In
+
+		Cfg       config.Cfg
+		CrashRepo CrashRepoAPI `optional:"false"`
+	}
+
+	CrashImplServiceFx struct {
+		p CrashServiceParamFx
+	}
+)
+
+func NewService(p CrashServiceParamFx) (CrashServiceAPI, error) {
+	if p.CrashRepo == nil {
+		return nil, errors.New("failed to load crash report repo")
+	}
+	return &CrashImplServiceFx{p}, nil
+}
+
+// NewReporter exposes the service as 'xpanic.Reporter' for http and grpc recovery.
+func NewReporter(s CrashServiceAPI) xpanic.Reporter {
+	return s
+}
+
+func (s *CrashImplServiceFx) Report(ctx context.Context, r xpanic.Report) error {
+	if r.Fingerprint == "" {
+		return errors.New("crash report fingerprint is empty")
+	}
+
+	maxSamples := s.p.Cfg.Crash.MaxSamples
+	if maxSamples <= 0 {
+		maxSamples = DefaultMaxSamples
+	}
+
+	_, err := s.p.CrashRepo.Upsert(ctx, r, maxSamples)
+	return err
+}
+
+func (s *CrashImplServiceFx) Read(ctx context.Context, fingerprint string) (*CrashReport, error) {
+	return s.p.CrashRepo.Read(ctx, fingerprint)
+}
+
+func (s *CrashImplServiceFx) ReadAll(ctx context.Context, limit int, offset int) ([]CrashReport, int64, error) {
+	return s.p.CrashRepo.ReadAll(ctx, limit, offset)
+}
@@ -3,21 +3,35 @@ package internal
 import (
 	"go.uber.org/fx"
 
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/internal/audit"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/internal/crash"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/internal/health"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/internal/notification"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/internal/storage"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/internal/user"
 )
 
 var (
 	RepoModules = fx.Options(
+		audit.RepoModules,
+		crash.RepoModules,
 		user.RepoModules,
 	)
 
 	ServiceModules = fx.Options(
+		audit.ServiceModules,
+		crash.ServiceModules,
+		notification.ServiceModules,
+		storage.ServiceModules,
 		user.ServiceModules,
 	)
 
 	HandlerModules = fx.Options(
+		audit.HandlerModules,
+		crash.HandlerModules,
 		health.HandlerModules,
+		notification.HandlerModules,
+		storage.HandlerModules,
 		user.HandlerModules,
 	)
 )
@@ -7,5 +7,6 @@ import (
 var (
 	HandlerModules = fx.Module("http:handler:module:health",
 		fx.Provide(NewHealthHandlerFx),
+		fx.Provide(NewHealthProbeHandlerFx),
 	)
 )
@@ -1,9 +1,14 @@
 package health
 
-import "github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+import (
+	"net/http"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
 
 type (
-	HealthResponseBody xresp.GeneralResponse[any, any]
+	HealthResponseBody xresp.GeneralResponse[xhealth.Report, any]
 )
 
 type (
@@ -13,3 +18,21 @@ type (
 		Status int
 	}
 )
+
+// NewHealthResponseOutput maps report into response, down report responds with 503,
+// so probes are failing while the report is still returned for debugging.
+func NewHealthResponseOutput(report xhealth.Report) *HealthResponseOutput {
+	status := http.StatusOK
+	if report.Status == xhealth.StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+
+	return &HealthResponseOutput{
+		Status: status,
+		Body: HealthResponseBody{
+			Code: status,
+			Msg:  string(report.Status),
+			Data: report,
+		},
+	}
+}
@@ -4,19 +4,20 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/rs/xid"
 	"go.uber.org/fx"
 
-	"github.com/Mind2Screen-Dev-Team/thousand-sunny/infra/http/middleware"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
 )
 
 type HealthHandlerParamFx struct {
 	fx.In
 
-	PrivateAuthJWT *middleware.PrivateAuthJWT
+	Registry *xhealth.Registry
 }
 
 type HealthHandlerFx struct {
@@ -39,64 +40,74 @@ func (h HealthHandlerFx) Register(api huma.API) {
 	huma.Register(api, h.Operation(), h.Serve)
 }
 
+func (h HealthHandlerFx) Version() string {
+	return "v1"
+}
+
 func (h HealthHandlerFx) Operation() huma.Operation {
 	return huma.Operation{
 		OperationID:   "api-check-health",
-		Path:          "/api/v1/health",
+		Path:          "/health",
 		Method:        http.MethodGet,
 		Summary:       "Service Health",
-		Description:   "Returns status ok if the service is healthy",
+		Description:   "Returns readiness report of the service and its dependencies, kept for backward compatibility, see '/readyz'",
 		DefaultStatus: http.StatusOK,
 		Tags:          []string{"Liveness"},
-		Responses: map[string]*huma.Response{
-			strconv.Itoa(http.StatusOK): {
-				Description: "Successful response",
-				Content: map[string]*huma.MediaType{
-					"application/json": {
-						Schema: &huma.Schema{
-							Ref: "schemas/HealthResponseBody",
-						},
-						Example: HealthResponseBody{
-							Code:    http.StatusOK,
-							Msg:     "ok",
-							Data:    nil,
-							Err:     nil,
-							TraceID: xid.New().String(),
-						},
+		Responses:     HealthOperationResponses(xhealth.KindReadiness),
+	}
+}
+
+func (h HealthHandlerFx) Serve(ctx context.Context, in *HealthRequestInput) (out *HealthResponseOutput, err error) {
+	return NewHealthResponseOutput(h.p.Registry.Run(ctx, xhealth.KindReadiness)), nil
+}
+
+func HealthOperationResponses(kind xhealth.Kind) map[string]*huma.Response {
+	example := func(status xhealth.Status, code int) HealthResponseBody {
+		check := xhealth.Result{
+			Name:      "postgres",
+			Status:    status,
+			Critical:  true,
+			Latency:   "1.2ms",
+			CheckedAt: time.Now(),
+		}
+		if status == xhealth.StatusDown {
+			check.Error = "context deadline exceeded"
+		}
+
+		return HealthResponseBody{
+			Code: code,
+			Msg:  string(status),
+			Data: xhealth.Report{
+				Kind:   kind,
+				Status: status,
+				Checks: []xhealth.Result{check},
+			},
+			TraceID: xid.New().String(),
+		}
+	}
+
+	return map[string]*huma.Response{
+		strconv.Itoa(http.StatusOK): {
+			Description: "Service is healthy or degraded by optional checks",
+			Content: map[string]*huma.MediaType{
+				"application/json": {
+					Schema: &huma.Schema{
+						Ref: "schemas/HealthResponseBody",
 					},
+					Example: example(xhealth.StatusUp, http.StatusOK),
 				},
 			},
-			strconv.Itoa(http.StatusInternalServerError): {
-				Description: "Failed response",
-				Content: map[string]*huma.MediaType{
-					"application/json": {
-						Schema: &huma.Schema{
-							Ref: "schemas/GeneralResponseError",
-						},
-						Example: HealthResponseBody{
-							Code:    http.StatusInternalServerError,
-							Msg:     http.StatusText(http.StatusInternalServerError),
-							Data:    nil,
-							Err:     nil,
-							TraceID: xid.New().String(),
-						},
+		},
+		strconv.Itoa(http.StatusServiceUnavailable): {
+			Description: "Service is unhealthy, starting or shutting down",
+			Content: map[string]*huma.MediaType{
+				"application/json": {
+					Schema: &huma.Schema{
+						Ref: "schemas/HealthResponseBody",
 					},
+					Example: example(xhealth.StatusDown, http.StatusServiceUnavailable),
 				},
 			},
 		},
 	}
 }
-
-func (h HealthHandlerFx) Serve(ctx context.Context, in *HealthRequestInput) (out *HealthResponseOutput, err error) {
-	var (
-		body = HealthResponseBody{
-			Code: http.StatusOK,
-			Msg:  "ok",
-		}
-		resp = HealthResponseOutput{
-			Status: http.StatusOK,
-			Body:   body,
-		}
-	)
-	return &resp, nil
-}
@@ -0,0 +1,63 @@
+package health
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+)
+
+type HealthProbeHandlerParamFx struct {
+	fx.In
+
+	Registry *xhealth.Registry
+}
+
+// HealthProbeHandlerFx serves kubernetes probes:
+//   - '/livez' only fails when the process itself is broken, dependencies are not checked.
+//   - '/readyz' fails when critical dependency is down or the service is shutting down.
+//   - '/startupz' fails until every fx start hook is done and critical dependencies are up.
+type HealthProbeHandlerFx struct {
+	p HealthProbeHandlerParamFx
+}
+
+type HealthProbeHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewHealthProbeHandlerFx(p HealthProbeHandlerParamFx) HealthProbeHandlerFxOut {
+	return HealthProbeHandlerFxOut{
+		Handler: &HealthProbeHandlerFx{p},
+	}
+}
+
+func (h HealthProbeHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation("api-probe-liveness", "/livez", "Liveness Probe", xhealth.KindLiveness), h.Serve(xhealth.KindLiveness))
+	huma.Register(api, h.Operation("api-probe-readiness", "/readyz", "Readiness Probe", xhealth.KindReadiness), h.Serve(xhealth.KindReadiness))
+	huma.Register(api, h.Operation("api-probe-startup", "/startupz", "Startup Probe", xhealth.KindStartup), h.Serve(xhealth.KindStartup))
+}
+
+func (h HealthProbeHandlerFx) Operation(id, path, summary string, kind xhealth.Kind) huma.Operation {
+	return huma.Operation{
+		OperationID:   id,
+		Path:          path,
+		Method:        http.MethodGet,
+		Summary:       summary,
+		Description:   "Returns " + string(kind) + " report with status, latency and error of every check",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Liveness"},
+		Responses:     HealthOperationResponses(kind),
+	}
+}
+
+func (h HealthProbeHandlerFx) Serve(kind xhealth.Kind) func(ctx context.Context, in *HealthRequestInput) (*HealthResponseOutput, error) {
+	return func(ctx context.Context, in *HealthRequestInput) (*HealthResponseOutput, error) {
+		return NewHealthResponseOutput(h.p.Registry.Run(ctx, kind)), nil
+	}
+}
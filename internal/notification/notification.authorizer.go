@@ -0,0 +1,43 @@
+package notification
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xsecurity"
+)
+
+const (
+	NotificationActionPublish   = "publish"
+	NotificationActionSubscribe = "subscribe"
+
+	// notificationUserTopicPrefix is topic of user notification, i.e: 'users.<id>', it belongs to the user only.
+	notificationUserTopicPrefix = "users."
+)
+
+// NotificationTopicAuthorizerAPI decides whether the verified principal of the request may publish or subscribe the topic,
+// replace it through 'fx.Decorate' to apply the topic rules of the application.
+type NotificationTopicAuthorizerAPI interface {
+	Authorize(ctx context.Context, action, topic string) error
+}
+
+type NotificationImplTopicAuthorizerFx struct{}
+
+func NewTopicAuthorizer() NotificationTopicAuthorizerAPI {
+	return NotificationImplTopicAuthorizerFx{}
+}
+
+// Authorize requires verified principal for every topic, and 'users.<id>' topic is only allowed for the principal
+// whose subject is the id.
+func (NotificationImplTopicAuthorizerFx) Authorize(ctx context.Context, action, topic string) error {
+	principal, ok := xsecurity.PrincipalFrom(ctx)
+	if !ok {
+		return ErrNotificationForbidden.Newf("%s notification topic requires authenticated request", action)
+	}
+
+	if id, ok := strings.CutPrefix(topic, notificationUserTopicPrefix); ok && id != principal.Subject {
+		return ErrNotificationForbidden.Newf("%s notification topic of the other user is not allowed", action)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpush"
+)
+
+type NotificationMessageData struct {
+	ID      string          `json:"id" doc:"Message ID" example:"cqbb0e2bdc0jqe6e0rlg"`
+	Topic   string          `json:"topic" doc:"Topic of the message" example:"jobs.1"`
+	Event   string          `json:"event" doc:"Event name of the message" example:"progress"`
+	Data    json.RawMessage `json:"data" doc:"Event payload"`
+	TraceID string          `json:"traceId,omitempty" doc:"Trace ID of the publisher request" example:"cqbb0e2bdc0jqe6e0rl0"`
+	Time    time.Time       `json:"time" doc:"Timestamp when the message was published" example:"2024-07-16T15:04:05Z" format:"date-time"`
+}
+
+func NewNotificationMessageData(msg xpush.Message) NotificationMessageData {
+	return NotificationMessageData{
+		ID:      msg.ID,
+		Topic:   msg.Topic,
+		Event:   msg.Event,
+		Data:    msg.Data,
+		TraceID: msg.TraceID,
+		Time:    msg.Time,
+	}
+}
+
+var (
+	ErrNotificationInvalidTopic = xerror.Define("NOTIFICATION_INVALID_TOPIC", http.StatusBadRequest, "invalid notification topic")
+	ErrNotificationUnavailable  = xerror.Define("NOTIFICATION_UNAVAILABLE", http.StatusServiceUnavailable, "notification hub is unavailable")
+	ErrNotificationForbidden    = xerror.Define("NOTIFICATION_TOPIC_FORBIDDEN", http.StatusForbidden, "notification topic is forbidden")
+)
+
+// NotificationError maps push error into catalogued error, the push error is kept as the cause.
+func NotificationError(msg string, err error) error {
+	switch {
+	case errors.Is(err, xpush.ErrInvalidTopic):
+		return ErrNotificationInvalidTopic.Wrap(err, msg)
+	case errors.Is(err, xpush.ErrHubClosed):
+		return ErrNotificationUnavailable.Wrap(err, msg)
+	}
+	return xerror.From(err, msg)
+}
@@ -0,0 +1,18 @@
+package notification
+
+import (
+	"go.uber.org/fx"
+)
+
+var (
+	ServiceModules = fx.Module("service:module:notification",
+		fx.Provide(NewTopicAuthorizer),
+		fx.Provide(NewService),
+	)
+
+	HandlerModules = fx.Module("http:handler:module:notification",
+		fx.Provide(NewPublishHandlerFx),
+		fx.Provide(NewSSEHandlerFx),
+		fx.Provide(NewWebSocketHandlerFx),
+	)
+)
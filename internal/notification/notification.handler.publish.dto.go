@@ -0,0 +1,26 @@
+package notification
+
+import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+type (
+	NotificationPublishRequestInput struct {
+		Topic string `path:"topic" example:"jobs.1" doc:"Topic name, it only contains alphanumeric, '.', '_', ':' or '-' characters" pattern:"^[a-zA-Z0-9._:-]{1,128}$" required:"true"`
+		Body  NotificationPublishRequestBody
+	}
+
+	NotificationPublishRequestBody struct {
+		Event string `json:"event" example:"progress" doc:"Event name" minLength:"1" maxLength:"64" pattern:"^[a-zA-Z0-9._:-]+$"`
+		Data  any    `json:"data" doc:"Event payload, any json value"`
+	}
+
+	NotificationPublishResponseOutput struct {
+		Body   NotificationPublishResponseBody
+		Status int
+	}
+)
+
+type (
+	NotificationPublishResponseBody xresp.GeneralResponse[*NotificationMessageData, any]
+)
@@ -0,0 +1,125 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type NotificationPublishHandlerParamFx struct {
+	fx.In
+
+	NotificationSvc NotificationServiceAPI
+	LogDebug        *xlog.DebugLogger
+}
+
+type NotificationPublishHandlerFx struct {
+	p      NotificationPublishHandlerParamFx
+	logger xlog.Logger
+}
+
+type NotificationPublishHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewPublishHandlerFx(p NotificationPublishHandlerParamFx) NotificationPublishHandlerFxOut {
+	return NotificationPublishHandlerFxOut{
+		Handler: &NotificationPublishHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h NotificationPublishHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation(), h.Serve)
+}
+
+func (h NotificationPublishHandlerFx) Version() string {
+	return "v1"
+}
+
+func (h NotificationPublishHandlerFx) Operation() huma.Operation {
+	return huma.Operation{
+		OperationID:   "api-publish-notification",
+		Path:          "/notifications/{topic}",
+		Method:        http.MethodPost,
+		Summary:       "Publish Notification",
+		Description:   "Publishes event into every SSE and WebSocket subscriber of the topic across instances.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Notifications"},
+		Metadata:      map[string]any{xhuma.MetadataMiddlewares: []string{"auth"}},
+		Responses: map[string]*huma.Response{
+			strconv.Itoa(http.StatusOK): {
+				Description: "Successful response",
+				Content: map[string]*huma.MediaType{
+					"application/json": {
+						Schema: &huma.Schema{
+							Ref: "schemas/NotificationPublishResponseBody",
+						},
+						Example: NotificationPublishResponseBody{
+							Code: http.StatusOK,
+							Msg:  "ok",
+							Data: &NotificationMessageData{
+								ID:      xid.New().String(),
+								Topic:   "jobs.1",
+								Event:   "progress",
+								Data:    []byte(`{"percent":50}`),
+								TraceID: xid.New().String(),
+								Time:    time.Now(),
+							},
+							TraceID: xid.New().String(),
+						},
+					},
+				},
+			},
+			strconv.Itoa(http.StatusInternalServerError): {
+				Description: "Failed response",
+				Content: map[string]*huma.MediaType{
+					"application/json": {
+						Schema: &huma.Schema{
+							Ref: "schemas/GeneralResponseError",
+						},
+						Example: NotificationPublishResponseBody{
+							Code:    http.StatusInternalServerError,
+							Msg:     http.StatusText(http.StatusInternalServerError),
+							TraceID: xid.New().String(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (h NotificationPublishHandlerFx) Serve(ctx context.Context, in *NotificationPublishRequestInput) (out *NotificationPublishResponseOutput, err error) {
+	msg, err := h.p.NotificationSvc.Publish(ctx, in.Topic, in.Body.Event, in.Body.Data)
+	if err != nil {
+		h.logger.Error(ctx, "failed to publish notification", "topic", in.Topic, "event", in.Body.Event, "err", fmt.Sprintf("%+v", err))
+		return nil, NotificationError("failed to publish notification", err)
+	}
+
+	var (
+		data = NewNotificationMessageData(*msg)
+		body = NotificationPublishResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &data,
+		}
+
+		resp = NotificationPublishResponseOutput{
+			Status: http.StatusOK,
+			Body:   body,
+		}
+	)
+
+	return &resp, nil
+}
@@ -0,0 +1,8 @@
+package notification
+
+type (
+	NotificationSSERequestInput struct {
+		Topic       string `path:"topic" example:"jobs.1" doc:"Topic name, it only contains alphanumeric, '.', '_', ':' or '-' characters" pattern:"^[a-zA-Z0-9._:-]{1,128}$" required:"true"`
+		AccessToken string `query:"access_token" doc:"Bearer token for client which is unable to send Authorization header, i.e: browser EventSource"`
+	}
+)
@@ -0,0 +1,71 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpush"
+)
+
+type NotificationSSEHandlerParamFx struct {
+	fx.In
+
+	NotificationSvc NotificationServiceAPI
+	Stream          xpush.StreamOptions
+	LogDebug        *xlog.DebugLogger
+}
+
+type NotificationSSEHandlerFx struct {
+	p      NotificationSSEHandlerParamFx
+	logger xlog.Logger
+}
+
+type NotificationSSEHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewSSEHandlerFx(p NotificationSSEHandlerParamFx) NotificationSSEHandlerFxOut {
+	return NotificationSSEHandlerFxOut{
+		Handler: &NotificationSSEHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h NotificationSSEHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation(), h.Serve)
+}
+
+func (h NotificationSSEHandlerFx) Version() string {
+	return "v1"
+}
+
+func (h NotificationSSEHandlerFx) Operation() huma.Operation {
+	return huma.Operation{
+		OperationID:   "api-sse-notification",
+		Path:          "/notifications/{topic}/events",
+		Method:        http.MethodGet,
+		Summary:       "Subscribe Notification Events",
+		Description:   "Streams published events of the topic as Server-Sent Events, heartbeat comment is sent periodically and the stream is closed when the server is draining.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Notifications"},
+		Metadata:      map[string]any{xhuma.MetadataMiddlewares: []string{"auth.stream"}},
+		Responses:     xpush.SSEOperationResponses(),
+	}
+}
+
+func (h NotificationSSEHandlerFx) Serve(ctx context.Context, in *NotificationSSERequestInput) (*huma.StreamResponse, error) {
+	sub, err := h.p.NotificationSvc.Subscribe(ctx, in.Topic)
+	if err != nil {
+		h.logger.Error(ctx, "failed to subscribe notification", "topic", in.Topic, "err", fmt.Sprintf("%+v", err))
+		return nil, NotificationError("failed to subscribe notification", err)
+	}
+
+	return xpush.SSE(sub, h.p.Stream), nil
+}
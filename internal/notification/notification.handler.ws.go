@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/infra/http/middleware"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpush"
+)
+
+type NotificationWebSocketHandlerParamFx struct {
+	fx.In
+
+	NotificationSvc NotificationServiceAPI
+	Auth            *middleware.PrivateAuthJWT
+	Stream          xpush.StreamOptions
+	LogDebug        *xlog.DebugLogger
+}
+
+// NotificationWebSocketHandlerFx is plain fiber route, so it is not documented in openapi,
+// the route goes through global middlewares (i.e: trace id) before the connection is upgraded.
+type NotificationWebSocketHandlerFx struct {
+	p      NotificationWebSocketHandlerParamFx
+	logger xlog.Logger
+}
+
+type NotificationWebSocketHandlerFxOut struct {
+	fx.Out
+
+	Handler xpush.WebSocketRegister `group:"global:http:websocket"`
+}
+
+func NewWebSocketHandlerFx(p NotificationWebSocketHandlerParamFx) NotificationWebSocketHandlerFxOut {
+	return NotificationWebSocketHandlerFxOut{
+		Handler: &NotificationWebSocketHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h NotificationWebSocketHandlerFx) Register(router fiber.Router) {
+	router.Get("/notifications/:topic/ws", h.p.Auth.Fiber, xpush.WebSocket(h.p.Stream, h.Subscribe))
+}
+
+func (h NotificationWebSocketHandlerFx) Version() string {
+	return "v1"
+}
+
+func (h NotificationWebSocketHandlerFx) Subscribe(c *fiber.Ctx) (*xpush.Subscription, error) {
+	var (
+		ctx   = c.UserContext()
+		topic = c.Params("topic")
+	)
+
+	sub, err := h.p.NotificationSvc.Subscribe(ctx, topic)
+	if err != nil {
+		h.logger.Error(ctx, "failed to subscribe notification", "topic", topic, "err", fmt.Sprintf("%+v", err))
+		return nil, NotificationError("failed to subscribe notification", err)
+	}
+
+	return sub, nil
+}
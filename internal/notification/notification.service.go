@@ -0,0 +1,75 @@
+package notification
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xpush"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtenant"
+)
+
+type NotificationServiceAPI interface {
+	Publish(ctx context.Context, topic, event string, data any) (*xpush.Message, error)
+	Subscribe(ctx context.Context, topic string) (*xpush.Subscription, error)
+}
+
+type (
+	NotificationServiceParamFx struct {
+		fx.In
+
+		Cfg        config.Cfg
+		Hub        *xpush.Hub
+		Authorizer NotificationTopicAuthorizerAPI
+	}
+
+	NotificationImplServiceFx struct {
+		p NotificationServiceParamFx
+	}
+)
+
+func NewService(p NotificationServiceParamFx) NotificationServiceAPI {
+	return &NotificationImplServiceFx{p}
+}
+
+// Publish pushes the event into every sse and websocket subscriber of the topic within the tenant of the request across instances,
+// i.e: topic 'jobs.<id>' for progress update or 'users.<id>' for user notification.
+func (s *NotificationImplServiceFx) Publish(ctx context.Context, topic, event string, data any) (*xpush.Message, error) {
+	scope, err := s.authorize(ctx, NotificationActionPublish, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := s.p.Hub.Publish(ctx, scope, topic, event, data)
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// Subscribe receives the topic within the tenant of the request, so a tenant never receives message of the other tenant.
+func (s *NotificationImplServiceFx) Subscribe(ctx context.Context, topic string) (*xpush.Subscription, error) {
+	scope, err := s.authorize(ctx, NotificationActionSubscribe, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.p.Hub.Subscribe(scope, topic)
+}
+
+// authorize checks the topic against the principal and returns the tenant as the topic scope,
+// the request without tenant is refused when tenancy is enabled.
+func (s *NotificationImplServiceFx) authorize(ctx context.Context, action, topic string) (string, error) {
+	if err := s.p.Authorizer.Authorize(ctx, action, topic); err != nil {
+		return "", err
+	}
+
+	tenant, ok := xtenant.FromContext(ctx)
+	if !ok && s.p.Cfg.Tenant.Enabled {
+		return "", xerror.ErrTenantRequired.New("notification topic can only be used within a tenant")
+	}
+
+	return tenant, nil
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xstorage"
+)
+
+type StorageObjectData struct {
+	Key         string    `json:"key" doc:"Object key" example:"avatars/user-1.png"`
+	Size        int64     `json:"size" doc:"Object size in bytes" example:"20480"`
+	ContentType string    `json:"contentType" doc:"Detected content type of the object" example:"image/png"`
+	Checksum    string    `json:"checksum,omitempty" doc:"Hex encoded SHA-256 checksum of the object" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	ModifiedAt  time.Time `json:"modifiedAt" doc:"Timestamp when the object was last modified" example:"2024-07-16T15:04:05Z" format:"date-time"`
+}
+
+func NewStorageObjectData(obj xstorage.Object) StorageObjectData {
+	return StorageObjectData(obj)
+}
+
+func ExampleStorageObjectData() StorageObjectData {
+	return StorageObjectData{
+		Key:         "avatars/user-1.png",
+		Size:        20480,
+		ContentType: "image/png",
+		Checksum:    "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		ModifiedAt:  time.Now(),
+	}
+}
+
+var (
+	ErrStorageObjectNotFound      = xerror.Define("STORAGE_OBJECT_NOT_FOUND", http.StatusNotFound, "storage object is not found")
+	ErrStorageInvalidKey          = xerror.Define("STORAGE_INVALID_KEY", http.StatusBadRequest, "invalid storage object key")
+	ErrStorageInvalidChecksum     = xerror.Define("STORAGE_INVALID_CHECKSUM", http.StatusBadRequest, "invalid storage object checksum")
+	ErrStorageInvalidSignature    = xerror.Define("STORAGE_INVALID_SIGNATURE", http.StatusForbidden, "invalid or expired presigned url")
+	ErrStorageObjectTooLarge      = xerror.Define("STORAGE_OBJECT_TOO_LARGE", http.StatusRequestEntityTooLarge, "storage object is too large")
+	ErrStorageContentTypeDenied   = xerror.Define("STORAGE_CONTENT_TYPE_DENIED", http.StatusUnsupportedMediaType, "content type is not allowed")
+	ErrStorageContentTypeMismatch = xerror.Define("STORAGE_CONTENT_TYPE_MISMATCH", http.StatusUnsupportedMediaType, "content type does not match the content")
+	ErrStorageChecksumMismatch    = xerror.Define("STORAGE_CHECKSUM_MISMATCH", http.StatusUnprocessableEntity, "checksum does not match the content")
+)
+
+// StorageError maps storage error into catalogued error, the storage error is kept as the cause.
+func StorageError(msg string, err error) error {
+	switch {
+	case errors.Is(err, xstorage.ErrNotFound):
+		return ErrStorageObjectNotFound.Wrap(err, msg)
+	case errors.Is(err, xstorage.ErrInvalidKey):
+		return ErrStorageInvalidKey.Wrap(err, msg)
+	case errors.Is(err, xstorage.ErrInvalidChecksum):
+		return ErrStorageInvalidChecksum.Wrap(err, msg)
+	case errors.Is(err, xstorage.ErrInvalidSignature):
+		return ErrStorageInvalidSignature.Wrap(err, msg)
+	case errors.Is(err, xstorage.ErrTooLarge):
+		return ErrStorageObjectTooLarge.Wrap(err, msg)
+	case errors.Is(err, xstorage.ErrContentTypeDenied):
+		return ErrStorageContentTypeDenied.Wrap(err, msg)
+	case errors.Is(err, xstorage.ErrContentTypeMismatch):
+		return ErrStorageContentTypeMismatch.Wrap(err, msg)
+	case errors.Is(err, xstorage.ErrChecksumMismatch):
+		return ErrStorageChecksumMismatch.Wrap(err, msg)
+	}
+	return xerror.From(err, msg)
+}
+
+// StorageOperationResponses documents success response and the given error status codes.
+func StorageOperationResponses(ref string, example any, codes ...int) map[string]*huma.Response {
+	responses := map[string]*huma.Response{
+		strconv.Itoa(http.StatusOK): {
+			Description: "Successful response",
+			Content: map[string]*huma.MediaType{
+				"application/json": {
+					Schema: &huma.Schema{
+						Ref: "schemas/" + ref,
+					},
+					Example: example,
+				},
+			},
+		},
+	}
+
+	for _, code := range append(codes, http.StatusInternalServerError) {
+		responses[strconv.Itoa(code)] = &huma.Response{
+			Description: http.StatusText(code),
+			Content: map[string]*huma.MediaType{
+				"application/json": {
+					Schema: &huma.Schema{
+						Ref: "schemas/GeneralResponseError",
+					},
+					Example: xresp.GeneralResponseError{
+						Code:    code,
+						Msg:     http.StatusText(code),
+						TraceID: xid.New().String(),
+					},
+				},
+			},
+		}
+	}
+
+	return responses
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"go.uber.org/fx"
+)
+
+var (
+	ServiceModules = fx.Module("service:module:storage",
+		fx.Provide(NewService),
+	)
+
+	HandlerModules = fx.Module("http:handler:module:storage",
+		fx.Provide(NewUploadHandlerFx),
+		fx.Provide(NewUploadMultipartHandlerFx),
+		fx.Provide(NewReadAllHandlerFx),
+		fx.Provide(NewReadHandlerFx),
+		fx.Provide(NewDeleteHandlerFx),
+		fx.Provide(NewPresignHandlerFx),
+		fx.Provide(NewSignedHandlerFx),
+	)
+)
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+type (
+	StorageDeleteRequestInput struct {
+		Key string `path:"key" example:"avatars%2Fuser-1.png" doc:"URL encoded object key" required:"true"`
+	}
+
+	StorageDeleteResponseOutput struct {
+		Body   StorageDeleteResponseBody
+		Status int
+	}
+)
+
+type (
+	StorageDeleteResponseBody xresp.GeneralResponse[any, any]
+)
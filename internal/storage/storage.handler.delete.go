@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type StorageDeleteHandlerParamFx struct {
+	fx.In
+
+	StorageSvc StorageServiceAPI
+	LogDebug   *xlog.DebugLogger
+}
+
+type StorageDeleteHandlerFx struct {
+	p      StorageDeleteHandlerParamFx
+	logger xlog.Logger
+}
+
+type StorageDeleteHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewDeleteHandlerFx(p StorageDeleteHandlerParamFx) StorageDeleteHandlerFxOut {
+	return StorageDeleteHandlerFxOut{
+		Handler: &StorageDeleteHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h StorageDeleteHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation(), h.Serve)
+}
+
+func (h StorageDeleteHandlerFx) Version() string {
+	return "v1"
+}
+
+func (h StorageDeleteHandlerFx) Operation() huma.Operation {
+	return huma.Operation{
+		OperationID:   "api-delete-storage-object",
+		Path:          "/storage/objects/{key}",
+		Method:        http.MethodDelete,
+		Summary:       "Delete Object",
+		Description:   "Deletes object by the given key.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Storage"},
+		Metadata:      map[string]any{xhuma.MetadataMiddlewares: []string{"auth"}},
+		Responses: StorageOperationResponses("StorageDeleteResponseBody", StorageDeleteResponseBody{
+			Code:    http.StatusOK,
+			Msg:     "ok",
+			TraceID: xid.New().String(),
+		}, http.StatusBadRequest, http.StatusNotFound),
+	}
+}
+
+func (h StorageDeleteHandlerFx) Serve(ctx context.Context, in *StorageDeleteRequestInput) (out *StorageDeleteResponseOutput, err error) {
+	if err := h.p.StorageSvc.Delete(ctx, in.Key); err != nil {
+		h.logger.Error(ctx, "failed to delete storage object", "key", in.Key, "err", fmt.Sprintf("%+v", err))
+		return nil, StorageError("failed to delete storage object", err)
+	}
+
+	var (
+		body = StorageDeleteResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+		}
+
+		resp = StorageDeleteResponseOutput{
+			Status: http.StatusOK,
+			Body:   body,
+		}
+	)
+
+	return &resp, nil
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+type (
+	StoragePresignRequestInput struct {
+		Key  string `path:"key" example:"avatars%2Fuser-1.png" doc:"URL encoded object key" required:"true"`
+		Body StoragePresignRequestBody
+	}
+
+	StoragePresignRequestBody struct {
+		Method string `json:"method" enum:"GET,PUT" default:"GET" example:"GET" doc:"HTTP method which is allowed by the presigned url, 'GET' to download and 'PUT' to upload"`
+	}
+
+	StoragePresignResponseOutput struct {
+		Body   StoragePresignResponseBody
+		Status int
+	}
+
+	StoragePresignResponseData struct {
+		URL       string    `json:"url" doc:"Presigned url" example:"http://localhost:8080/api/v1/storage/signed/avatars%2Fuser-1.png?expires=1721142245&signature=4f1c..."`
+		Method    string    `json:"method" doc:"HTTP method which is allowed by the presigned url" example:"GET"`
+		ExpiresAt time.Time `json:"expiresAt" doc:"Timestamp when the presigned url is expired" example:"2024-07-16T15:04:05Z" format:"date-time"`
+	}
+)
+
+type (
+	StoragePresignResponseBody xresp.GeneralResponse[*StoragePresignResponseData, any]
+)
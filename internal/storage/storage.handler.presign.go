@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type StoragePresignHandlerParamFx struct {
+	fx.In
+
+	StorageSvc StorageServiceAPI
+	LogDebug   *xlog.DebugLogger
+}
+
+type StoragePresignHandlerFx struct {
+	p      StoragePresignHandlerParamFx
+	logger xlog.Logger
+}
+
+type StoragePresignHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewPresignHandlerFx(p StoragePresignHandlerParamFx) StoragePresignHandlerFxOut {
+	return StoragePresignHandlerFxOut{
+		Handler: &StoragePresignHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h StoragePresignHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation(), h.Serve)
+}
+
+func (h StoragePresignHandlerFx) Version() string {
+	return "v1"
+}
+
+func (h StoragePresignHandlerFx) Operation() huma.Operation {
+	return huma.Operation{
+		OperationID:   "api-presign-storage-object",
+		Path:          "/storage/objects/{key}/presign",
+		Method:        http.MethodPost,
+		Summary:       "Presign Object URL",
+		Description:   "Generates time limited url to download or upload the object directly, the expiry is configured by 'storage.presign.expiry'.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Storage"},
+		Metadata:      map[string]any{xhuma.MetadataMiddlewares: []string{"auth"}},
+		Responses: StorageOperationResponses("StoragePresignResponseBody", StoragePresignResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &StoragePresignResponseData{
+				URL:       "http://localhost:8080/api/v1/storage/signed/avatars%2Fuser-1.png?expires=1721142245&signature=4f1c...",
+				Method:    http.MethodGet,
+				ExpiresAt: time.Now().Add(15 * time.Minute),
+			},
+			TraceID: xid.New().String(),
+		}, http.StatusBadRequest, http.StatusNotFound),
+	}
+}
+
+func (h StoragePresignHandlerFx) Serve(ctx context.Context, in *StoragePresignRequestInput) (out *StoragePresignResponseOutput, err error) {
+	presigned, err := h.p.StorageSvc.Presign(ctx, in.Key, in.Body.Method)
+	if err != nil {
+		h.logger.Error(ctx, "failed to presign storage object", "key", in.Key, "method", in.Body.Method, "err", fmt.Sprintf("%+v", err))
+		return nil, StorageError("failed to presign storage object", err)
+	}
+
+	var (
+		body = StoragePresignResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &StoragePresignResponseData{
+				URL:       presigned.URL,
+				Method:    presigned.Method,
+				ExpiresAt: presigned.ExpiresAt,
+			},
+		}
+
+		resp = StoragePresignResponseOutput{
+			Status: http.StatusOK,
+			Body:   body,
+		}
+	)
+
+	return &resp, nil
+}
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+type (
+	StorageReadAllRequestInput struct {
+		Prefix string `query:"prefix" example:"avatars/" doc:"Only list objects which key starts with the prefix"`
+	}
+
+	StorageReadAllResponseOutput struct {
+		Body   StorageReadAllResponseBody
+		Status int
+	}
+)
+
+type (
+	StorageReadAllResponseBody xresp.GeneralResponse[[]StorageObjectData, any]
+)
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type StorageReadAllHandlerParamFx struct {
+	fx.In
+
+	StorageSvc StorageServiceAPI
+	LogDebug   *xlog.DebugLogger
+}
+
+type StorageReadAllHandlerFx struct {
+	p      StorageReadAllHandlerParamFx
+	logger xlog.Logger
+}
+
+type StorageReadAllHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewReadAllHandlerFx(p StorageReadAllHandlerParamFx) StorageReadAllHandlerFxOut {
+	return StorageReadAllHandlerFxOut{
+		Handler: &StorageReadAllHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h StorageReadAllHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation(), h.Serve)
+}
+
+func (h StorageReadAllHandlerFx) Version() string {
+	return "v1"
+}
+
+func (h StorageReadAllHandlerFx) Operation() huma.Operation {
+	return huma.Operation{
+		OperationID:   "api-read-all-storage-object",
+		Path:          "/storage/objects",
+		Method:        http.MethodGet,
+		Summary:       "Retrieves All Objects",
+		Description:   "Retrieves metadata of all objects, optionally filtered by key prefix.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Storage"},
+		Metadata:      map[string]any{xhuma.MetadataMiddlewares: []string{"auth"}},
+		Responses: StorageOperationResponses("StorageReadAllResponseBody", StorageReadAllResponseBody{
+			Code:    http.StatusOK,
+			Msg:     "ok",
+			Data:    []StorageObjectData{ExampleStorageObjectData()},
+			TraceID: xid.New().String(),
+		}),
+	}
+}
+
+func (h StorageReadAllHandlerFx) Serve(ctx context.Context, in *StorageReadAllRequestInput) (out *StorageReadAllResponseOutput, err error) {
+	objects, err := h.p.StorageSvc.ReadAll(ctx, in.Prefix)
+	if err != nil {
+		h.logger.Error(ctx, "failed to read all storage object", "prefix", in.Prefix, "err", fmt.Sprintf("%+v", err))
+		return nil, StorageError("failed to read all storage object", err)
+	}
+
+	data := make([]StorageObjectData, len(objects))
+	for i, v := range objects {
+		data[i] = NewStorageObjectData(v)
+	}
+
+	var (
+		body = StorageReadAllResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: data,
+		}
+
+		resp = StorageReadAllResponseOutput{
+			Status: http.StatusOK,
+			Body:   body,
+		}
+	)
+
+	return &resp, nil
+}
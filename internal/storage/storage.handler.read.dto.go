@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+type (
+	StorageReadRequestInput struct {
+		Key string `path:"key" example:"avatars%2Fuser-1.png" doc:"URL encoded object key" required:"true"`
+	}
+
+	StorageReadResponseOutput struct {
+		Body   StorageReadResponseBody
+		Status int
+	}
+)
+
+type (
+	StorageReadResponseBody xresp.GeneralResponse[*StorageObjectData, any]
+)
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type StorageReadHandlerParamFx struct {
+	fx.In
+
+	StorageSvc StorageServiceAPI
+	LogDebug   *xlog.DebugLogger
+}
+
+type StorageReadHandlerFx struct {
+	p      StorageReadHandlerParamFx
+	logger xlog.Logger
+}
+
+type StorageReadHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewReadHandlerFx(p StorageReadHandlerParamFx) StorageReadHandlerFxOut {
+	return StorageReadHandlerFxOut{
+		Handler: &StorageReadHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h StorageReadHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation(), h.Serve)
+}
+
+func (h StorageReadHandlerFx) Version() string {
+	return "v1"
+}
+
+func (h StorageReadHandlerFx) Operation() huma.Operation {
+	example := ExampleStorageObjectData()
+
+	return huma.Operation{
+		OperationID:   "api-read-storage-object",
+		Path:          "/storage/objects/{key}",
+		Method:        http.MethodGet,
+		Summary:       "Retrieves Object Metadata",
+		Description:   "Retrieves object metadata by the given key, use presigned url to download the content.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Storage"},
+		Metadata:      map[string]any{xhuma.MetadataMiddlewares: []string{"auth"}},
+		Responses: StorageOperationResponses("StorageReadResponseBody", StorageReadResponseBody{
+			Code:    http.StatusOK,
+			Msg:     "ok",
+			Data:    &example,
+			TraceID: xid.New().String(),
+		}, http.StatusBadRequest, http.StatusNotFound),
+	}
+}
+
+func (h StorageReadHandlerFx) Serve(ctx context.Context, in *StorageReadRequestInput) (out *StorageReadResponseOutput, err error) {
+	obj, err := h.p.StorageSvc.Read(ctx, in.Key)
+	if err != nil {
+		h.logger.Error(ctx, "failed to read storage object", "key", in.Key, "err", fmt.Sprintf("%+v", err))
+		return nil, StorageError("failed to read storage object", err)
+	}
+
+	var (
+		data = NewStorageObjectData(*obj)
+		body = StorageReadResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &data,
+		}
+
+		resp = StorageReadResponseOutput{
+			Status: http.StatusOK,
+			Body:   body,
+		}
+	)
+
+	return &resp, nil
+}
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/rs/xid"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type StorageSignedHandlerParamFx struct {
+	fx.In
+
+	StorageSvc StorageServiceAPI
+	LogDebug   *xlog.DebugLogger
+}
+
+// StorageSignedHandlerFx serves presigned url of local storage driver,
+// S3-compatible driver presigns url to the bucket directly, so every request here is rejected.
+type StorageSignedHandlerFx struct {
+	p      StorageSignedHandlerParamFx
+	logger xlog.Logger
+}
+
+type StorageSignedHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewSignedHandlerFx(p StorageSignedHandlerParamFx) StorageSignedHandlerFxOut {
+	return StorageSignedHandlerFxOut{
+		Handler: &StorageSignedHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h StorageSignedHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.DownloadOperation(), h.Download)
+	huma.Register(api, h.UploadOperation(), h.Upload)
+}
+
+func (h StorageSignedHandlerFx) Version() string {
+	return "v1"
+}
+
+func (h StorageSignedHandlerFx) DownloadOperation() huma.Operation {
+	return huma.Operation{
+		OperationID:   "api-signed-download-storage-object",
+		Path:          "/storage/signed/{key}",
+		Method:        http.MethodGet,
+		Summary:       "Download Object By Presigned URL",
+		Description:   "Streams object content, the url must be generated by presign object url endpoint with 'GET' method.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Storage"},
+		Responses: map[string]*huma.Response{
+			strconv.Itoa(http.StatusOK): {
+				Description: "Object content",
+				Content: map[string]*huma.MediaType{
+					"application/octet-stream": {
+						Schema: &huma.Schema{Type: huma.TypeString, Format: "binary"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (h StorageSignedHandlerFx) UploadOperation() huma.Operation {
+	example := ExampleStorageObjectData()
+
+	return huma.Operation{
+		OperationID:   "api-signed-upload-storage-object",
+		Path:          "/storage/signed/{key}",
+		Method:        http.MethodPut,
+		Summary:       "Upload Object By Presigned URL",
+		Description:   "Streams raw request body into object storage, the url must be generated by presign object url endpoint with 'PUT' method.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Storage"},
+		RequestBody: &huma.RequestBody{
+			Required: true,
+			Content: map[string]*huma.MediaType{
+				"application/octet-stream": {
+					Schema: &huma.Schema{Type: huma.TypeString, Format: "binary"},
+				},
+			},
+		},
+		Responses: StorageOperationResponses("StorageUploadResponseBody", StorageUploadResponseBody{
+			Code:    http.StatusOK,
+			Msg:     "ok",
+			Data:    &example,
+			TraceID: xid.New().String(),
+		}, http.StatusBadRequest, http.StatusForbidden, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity),
+	}
+}
+
+func (h StorageSignedHandlerFx) Download(ctx context.Context, in *StorageSignedDownloadRequestInput) (*huma.StreamResponse, error) {
+	if err := h.p.StorageSvc.VerifySigned(http.MethodGet, in.Key, in.Expires, in.Signature); err != nil {
+		return nil, StorageError("failed to verify presigned url", err)
+	}
+
+	r, obj, err := h.p.StorageSvc.Open(ctx, in.Key)
+	if err != nil {
+		h.logger.Error(ctx, "failed to open storage object", "key", in.Key, "err", fmt.Sprintf("%+v", err))
+		return nil, StorageError("failed to open storage object", err)
+	}
+
+	return &huma.StreamResponse{
+		Body: func(ctx huma.Context) {
+			contentType := obj.ContentType
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+
+			ctx.SetHeader("Content-Type", contentType)
+			if obj.Checksum != "" {
+				ctx.SetHeader("ETag", strconv.Quote(obj.Checksum))
+			}
+
+			// fasthttp closes the reader once the body is fully written
+			humafiber.Unwrap(ctx).Status(http.StatusOK).SendStream(r, int(obj.Size))
+		},
+	}, nil
+}
+
+func (h StorageSignedHandlerFx) Upload(ctx context.Context, in *StorageSignedUploadRequestInput) (*StorageUploadResponseOutput, error) {
+	if err := h.p.StorageSvc.VerifySigned(http.MethodPut, in.Key, in.Expires, in.Signature); err != nil {
+		return nil, StorageError("failed to verify presigned url", err)
+	}
+
+	obj, err := h.p.StorageSvc.UploadSigned(ctx, StorageUpload{
+		Key:         in.Key,
+		ContentType: in.ContentType,
+		Size:        in.ContentLength,
+		Checksum:    in.Checksum,
+		Body:        in.body,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "failed to upload storage object", "key", in.Key, "err", fmt.Sprintf("%+v", err))
+		return nil, StorageError("failed to upload storage object", err)
+	}
+
+	var (
+		data = NewStorageObjectData(*obj)
+		body = StorageUploadResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &data,
+		}
+
+		resp = StorageUploadResponseOutput{
+			Status: http.StatusOK,
+			Body:   body,
+		}
+	)
+
+	return &resp, nil
+}
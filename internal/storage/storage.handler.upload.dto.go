@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"io"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+type (
+	StorageUploadRequestInput struct {
+		Key           string `path:"key" example:"avatars%2Fuser-1.png" doc:"URL encoded object key" required:"true"`
+		ContentType   string `header:"Content-Type" example:"image/png" doc:"Declared content type, it must match the sniffed content"`
+		ContentLength int64  `header:"Content-Length" example:"20480" doc:"Content size in bytes"`
+		Checksum      string `header:"X-Checksum-SHA256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" doc:"Hex or base64 encoded SHA-256 of the content, the upload is rejected when it does not match"`
+
+		body io.Reader
+	}
+
+	StorageUploadResponseOutput struct {
+		Body   StorageUploadResponseBody
+		Status int
+	}
+)
+
+// Resolve keeps request body as stream, so the content is never fully buffered by huma.
+func (in *StorageUploadRequestInput) Resolve(ctx huma.Context) []error {
+	in.body = ctx.BodyReader()
+	return nil
+}
+
+type (
+	StorageUploadResponseBody xresp.GeneralResponse[*StorageObjectData, any]
+)
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+type StorageUploadHandlerParamFx struct {
+	fx.In
+
+	StorageSvc StorageServiceAPI
+	LogDebug   *xlog.DebugLogger
+}
+
+type StorageUploadHandlerFx struct {
+	p      StorageUploadHandlerParamFx
+	logger xlog.Logger
+}
+
+type StorageUploadHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewUploadHandlerFx(p StorageUploadHandlerParamFx) StorageUploadHandlerFxOut {
+	return StorageUploadHandlerFxOut{
+		Handler: &StorageUploadHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h StorageUploadHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation(), h.Serve)
+}
+
+func (h StorageUploadHandlerFx) Version() string {
+	return "v1"
+}
+
+func (h StorageUploadHandlerFx) Operation() huma.Operation {
+	example := ExampleStorageObjectData()
+
+	return huma.Operation{
+		OperationID:   "api-upload-storage-object",
+		Path:          "/storage/objects/{key}",
+		Method:        http.MethodPut,
+		Summary:       "Upload Object",
+		Description:   "Streams raw request body into object storage, content type is sniffed and the size is limited by 'storage.upload' config.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Storage"},
+		Metadata:      map[string]any{xhuma.MetadataMiddlewares: []string{"auth"}},
+		RequestBody: &huma.RequestBody{
+			Required: true,
+			Content: map[string]*huma.MediaType{
+				"application/octet-stream": {
+					Schema: &huma.Schema{Type: huma.TypeString, Format: "binary"},
+				},
+			},
+		},
+		Responses: StorageOperationResponses("StorageUploadResponseBody", StorageUploadResponseBody{
+			Code:    http.StatusOK,
+			Msg:     "ok",
+			Data:    &example,
+			TraceID: xid.New().String(),
+		}, http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity),
+	}
+}
+
+func (h StorageUploadHandlerFx) Serve(ctx context.Context, in *StorageUploadRequestInput) (out *StorageUploadResponseOutput, err error) {
+	obj, err := h.p.StorageSvc.Upload(ctx, StorageUpload{
+		Key:         in.Key,
+		ContentType: in.ContentType,
+		Size:        in.ContentLength,
+		Checksum:    in.Checksum,
+		Body:        in.body,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "failed to upload storage object", "key", in.Key, "err", fmt.Sprintf("%+v", err))
+		return nil, StorageError("failed to upload storage object", err)
+	}
+
+	var (
+		data = NewStorageObjectData(*obj)
+		body = StorageUploadResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &data,
+		}
+
+		resp = StorageUploadResponseOutput{
+			Status: http.StatusOK,
+			Body:   body,
+		}
+	)
+
+	return &resp, nil
+}
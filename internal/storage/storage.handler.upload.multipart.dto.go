@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"io"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type (
+	StorageUploadMultipartRequestInput struct {
+		ContentType string `header:"Content-Type" doc:"Must be 'multipart/form-data' with boundary" required:"true"`
+		Checksum    string `header:"X-Checksum-SHA256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" doc:"Hex or base64 encoded SHA-256 of the file part"`
+
+		body io.Reader
+	}
+)
+
+// Resolve keeps request body as stream, multipart parts are read one by one instead of being buffered.
+func (in *StorageUploadMultipartRequestInput) Resolve(ctx huma.Context) []error {
+	in.body = ctx.BodyReader()
+	return nil
+}
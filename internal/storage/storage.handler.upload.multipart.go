@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/xid"
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+const (
+	// maxMultipartKeyBytes limits the 'key' form field which is read into memory.
+	maxMultipartKeyBytes = 1024
+)
+
+type StorageUploadMultipartHandlerParamFx struct {
+	fx.In
+
+	StorageSvc StorageServiceAPI
+	LogDebug   *xlog.DebugLogger
+}
+
+type StorageUploadMultipartHandlerFx struct {
+	p      StorageUploadMultipartHandlerParamFx
+	logger xlog.Logger
+}
+
+type StorageUploadMultipartHandlerFxOut struct {
+	fx.Out
+
+	Handler xhuma.HandlerRegister `group:"global:http:handler"`
+}
+
+func NewUploadMultipartHandlerFx(p StorageUploadMultipartHandlerParamFx) StorageUploadMultipartHandlerFxOut {
+	return StorageUploadMultipartHandlerFxOut{
+		Handler: &StorageUploadMultipartHandlerFx{p: p, logger: xlog.NewLogger(p.LogDebug.Logger)},
+	}
+}
+
+func (h StorageUploadMultipartHandlerFx) Register(api huma.API) {
+	huma.Register(api, h.Operation(), h.Serve)
+}
+
+func (h StorageUploadMultipartHandlerFx) Version() string {
+	return "v1"
+}
+
+func (h StorageUploadMultipartHandlerFx) Operation() huma.Operation {
+	example := ExampleStorageObjectData()
+
+	return huma.Operation{
+		OperationID:   "api-upload-storage-object-multipart",
+		Path:          "/storage/objects",
+		Method:        http.MethodPost,
+		Summary:       "Upload Object Multipart",
+		Description:   "Streams 'file' part of multipart form into object storage. Optional 'key' field must be sent before 'file', otherwise the key is generated under 'uploads/'.",
+		DefaultStatus: http.StatusOK,
+		Tags:          []string{"Storage"},
+		Metadata:      map[string]any{xhuma.MetadataMiddlewares: []string{"auth"}},
+		RequestBody: &huma.RequestBody{
+			Required: true,
+			Content: map[string]*huma.MediaType{
+				"multipart/form-data": {
+					Schema: &huma.Schema{
+						Type:     huma.TypeObject,
+						Required: []string{"file"},
+						Properties: map[string]*huma.Schema{
+							"key":  {Type: huma.TypeString, Description: "Object key, i.e: 'avatars/user-1.png'"},
+							"file": {Type: huma.TypeString, Format: "binary", Description: "File content"},
+						},
+					},
+				},
+			},
+		},
+		Responses: StorageOperationResponses("StorageUploadResponseBody", StorageUploadResponseBody{
+			Code:    http.StatusOK,
+			Msg:     "ok",
+			Data:    &example,
+			TraceID: xid.New().String(),
+		}, http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity),
+	}
+}
+
+func (h StorageUploadMultipartHandlerFx) Serve(ctx context.Context, in *StorageUploadMultipartRequestInput) (out *StorageUploadResponseOutput, err error) {
+	mt, params, err := mime.ParseMediaType(in.ContentType)
+	if err != nil || mt != "multipart/form-data" || params["boundary"] == "" {
+		return nil, huma.Error415UnsupportedMediaType("content type must be 'multipart/form-data' with boundary")
+	}
+
+	var (
+		key    string
+		reader = multipart.NewReader(in.body, params["boundary"])
+	)
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, huma.Error400BadRequest("multipart form field 'file' is required")
+		}
+		if err != nil {
+			return nil, huma.Error400BadRequest("failed to read multipart form", err)
+		}
+
+		switch part.FormName() {
+		case "key":
+			b, err := io.ReadAll(io.LimitReader(part, maxMultipartKeyBytes))
+			if err != nil {
+				return nil, huma.Error400BadRequest("failed to read multipart form field 'key'", err)
+			}
+			key = strings.TrimSpace(string(b))
+		case "file":
+			if key == "" {
+				key = "uploads/" + xid.New().String() + strings.ToLower(path.Ext(part.FileName()))
+			}
+			return h.upload(ctx, key, in.Checksum, part)
+		}
+	}
+}
+
+func (h StorageUploadMultipartHandlerFx) upload(ctx context.Context, key, checksum string, part *multipart.Part) (*StorageUploadResponseOutput, error) {
+	defer part.Close()
+
+	// most clients send 'application/octet-stream' for file part, so the file extension is more accurate
+	contentType := part.Header.Get("Content-Type")
+	if ext := path.Ext(part.FileName()); ext != "" && (contentType == "" || contentType == "application/octet-stream") {
+		contentType = mime.TypeByExtension(strings.ToLower(ext))
+	}
+
+	obj, err := h.p.StorageSvc.Upload(ctx, StorageUpload{
+		Key:         key,
+		ContentType: contentType,
+		Checksum:    checksum,
+		Body:        part,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "failed to upload storage object", "key", key, "err", fmt.Sprintf("%+v", err))
+		return nil, StorageError("failed to upload storage object", err)
+	}
+
+	var (
+		data = NewStorageObjectData(*obj)
+		body = StorageUploadResponseBody{
+			Code: http.StatusOK,
+			Msg:  "ok",
+			Data: &data,
+		}
+
+		resp = StorageUploadResponseOutput{
+			Status: http.StatusOK,
+			Body:   body,
+		}
+	)
+
+	return &resp, nil
+}
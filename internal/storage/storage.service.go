@@ -0,0 +1,286 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/config"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xstorage"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtenant"
+)
+
+const tenantKeyPrefix = "tenants/"
+
+type StorageServiceAPI interface {
+	Upload(ctx context.Context, in StorageUpload) (*xstorage.Object, error)
+	UploadSigned(ctx context.Context, in StorageUpload) (*xstorage.Object, error)
+	Read(ctx context.Context, key string) (*xstorage.Object, error)
+	ReadAll(ctx context.Context, prefix string) ([]xstorage.Object, error)
+	Delete(ctx context.Context, key string) error
+	Presign(ctx context.Context, key string, method string) (*StoragePresigned, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, *xstorage.Object, error)
+	VerifySigned(method, key string, expires int64, signature string) error
+}
+
+type (
+	StorageUpload struct {
+		Key         string
+		ContentType string
+		Size        int64
+		Checksum    string
+		Body        io.Reader
+	}
+
+	StoragePresigned struct {
+		URL       string
+		Method    string
+		ExpiresAt time.Time
+	}
+)
+
+type (
+	StorageServiceParamFx struct {
+		fx.In
+
+		Cfg     config.Cfg
+		Storage xstorage.Storage
+	}
+
+	StorageImplServiceFx struct {
+		p StorageServiceParamFx
+	}
+)
+
+func NewService(p StorageServiceParamFx) StorageServiceAPI {
+	return &StorageImplServiceFx{p}
+}
+
+// Upload streams the body into storage under the tenant of the request, content type is sniffed and size is limited
+// by 'storage.upload' config, checksum is verified by the storage backend.
+func (s *StorageImplServiceFx) Upload(ctx context.Context, in StorageUpload) (*xstorage.Object, error) {
+	key, err := s.decodeTenantKey(ctx, in.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := s.put(ctx, key, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.untenantObject(ctx, obj), nil
+}
+
+// UploadSigned streams the body into the presigned key as is, the key is already scoped into the tenant by Presign.
+func (s *StorageImplServiceFx) UploadSigned(ctx context.Context, in StorageUpload) (*xstorage.Object, error) {
+	key, err := DecodeKey(in.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.put(ctx, key, in)
+}
+
+func (s *StorageImplServiceFx) put(ctx context.Context, key string, in StorageUpload) (*xstorage.Object, error) {
+	var (
+		cfg    = s.p.Cfg.Storage.Upload
+		policy = xstorage.UploadPolicy{MaxSize: cfg.MaxSize, AllowedTypes: cfg.AllowedTypes}
+	)
+
+	// reject early by declared size, the actual size is still enforced while streaming
+	if policy.MaxSize > 0 && in.Size > policy.MaxSize {
+		return nil, fmt.Errorf("%w: limit is %d bytes", xstorage.ErrTooLarge, policy.MaxSize)
+	}
+
+	r, err := xstorage.NewUploadReader(in.Body, in.ContentType, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	size := in.Size
+	if size <= 0 {
+		size = -1
+	}
+
+	obj, err := s.p.Storage.Put(ctx, key, r, xstorage.PutOptions{
+		ContentType: r.ContentType,
+		Size:        size,
+		Checksum:    in.Checksum,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
+
+func (s *StorageImplServiceFx) Read(ctx context.Context, key string) (*xstorage.Object, error) {
+	key, err := s.decodeTenantKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := s.p.Storage.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.untenantObject(ctx, &obj), nil
+}
+
+func (s *StorageImplServiceFx) ReadAll(ctx context.Context, prefix string) ([]xstorage.Object, error) {
+	scope, err := s.tenantPrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := s.p.Storage.List(ctx, scope+strings.TrimPrefix(prefix, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	// the unscoped prefix may still match tenant objects, i.e: 'ten', they are never listed
+	result := make([]xstorage.Object, 0, len(objects))
+	for i := range objects {
+		if scope == "" && strings.HasPrefix(objects[i].Key, tenantKeyPrefix) {
+			continue
+		}
+		result = append(result, *s.untenantObject(ctx, &objects[i]))
+	}
+
+	return result, nil
+}
+
+func (s *StorageImplServiceFx) Delete(ctx context.Context, key string) error {
+	key, err := s.decodeTenantKey(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	return s.p.Storage.Delete(ctx, key)
+}
+
+// Presign signs the tenant scoped key, so the presigned url is served without the tenant of the request.
+func (s *StorageImplServiceFx) Presign(ctx context.Context, key string, method string) (*StoragePresigned, error) {
+	key, err := s.decodeTenantKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		expiry = time.Duration(max(s.p.Cfg.Storage.PresignExpiry, 60)) * time.Second
+		u      string
+	)
+
+	switch method = strings.ToUpper(method); method {
+	case http.MethodGet:
+		if _, err := s.p.Storage.Stat(ctx, key); err != nil {
+			return nil, err
+		}
+		u, err = s.p.Storage.PresignGet(ctx, key, expiry)
+	case http.MethodPut:
+		u, err = s.p.Storage.PresignPut(ctx, key, expiry)
+	default:
+		return nil, fmt.Errorf("unsupported presign method '%s'", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoragePresigned{URL: u, Method: method, ExpiresAt: time.Now().Add(expiry)}, nil
+}
+
+// Open opens the presigned key as is, the key is already scoped into the tenant by Presign.
+func (s *StorageImplServiceFx) Open(ctx context.Context, key string) (io.ReadCloser, *xstorage.Object, error) {
+	key, err := DecodeKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, obj, err := s.p.Storage.Get(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r, &obj, nil
+}
+
+// VerifySigned verifies presigned url of local storage, other backends serve presigned url by themselves.
+func (s *StorageImplServiceFx) VerifySigned(method, key string, expires int64, signature string) error {
+	local, ok := s.p.Storage.(*xstorage.LocalStorage)
+	if !ok {
+		return xstorage.ErrInvalidSignature
+	}
+
+	key, err := DecodeKey(key)
+	if err != nil {
+		return err
+	}
+
+	return local.Verify(method, key, expires, signature)
+}
+
+// DecodeKey decodes url encoded object key from path parameter, i.e: 'avatars%2Fuser-1.png'.
+func DecodeKey(key string) (string, error) {
+	decoded, err := url.PathUnescape(key)
+	if err != nil {
+		return "", xstorage.ErrInvalidKey
+	}
+	return xstorage.CleanKey(decoded)
+}
+
+// decodeTenantKey decodes object key and scopes it into the tenant of the request, i.e: 'tenants/acme/avatars/user-1.png',
+// so a tenant never reaches object of the other tenant. The key is not scoped when tenancy is disabled,
+// and the key under tenant prefix is refused, so it never reaches the tenant object either.
+func (s *StorageImplServiceFx) decodeTenantKey(ctx context.Context, key string) (string, error) {
+	scope, err := s.tenantPrefix(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	key, err = DecodeKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.HasPrefix(key, tenantKeyPrefix) {
+		return "", xstorage.ErrInvalidKey
+	}
+
+	return scope + key, nil
+}
+
+// tenantPrefix returns object key prefix of the tenant of the request, it is empty when tenancy is disabled,
+// and the request without tenant is refused when tenancy is enabled.
+func (s *StorageImplServiceFx) tenantPrefix(ctx context.Context) (string, error) {
+	id, ok := xtenant.FromContext(ctx)
+	if !ok {
+		if s.p.Cfg.Tenant.Enabled {
+			return "", xerror.ErrTenantRequired.New("storage object can only be accessed within a tenant")
+		}
+		return "", nil
+	}
+
+	// tenant id must be a single key segment, so it can not escape its prefix
+	if id == "." || id == ".." || strings.ContainsAny(id, "/\\") {
+		return "", xstorage.ErrInvalidKey
+	}
+
+	return tenantKeyPrefix + id + "/", nil
+}
+
+// untenantObject trims tenant prefix from the object key, so the client only sees its own key.
+func (s *StorageImplServiceFx) untenantObject(ctx context.Context, obj *xstorage.Object) *xstorage.Object {
+	if scope, err := s.tenantPrefix(ctx); err == nil {
+		obj.Key = strings.TrimPrefix(obj.Key, scope)
+	}
+	return obj
+}
@@ -1,9 +1,16 @@
 package user
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+)
+
+var (
+	ErrUserNotFound = xerror.Define("USER_NOT_FOUND", http.StatusNotFound, "user is not found")
 )
 
 type (
@@ -12,6 +12,7 @@ import (
 	"github.com/rs/xid"
 	"go.uber.org/fx"
 
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
@@ -45,15 +46,23 @@ func (h ExampleUserCreateHandlerFx) Register(api huma.API) {
 	huma.Register(api, h.Operation(), h.Serve)
 }
 
+func (h ExampleUserCreateHandlerFx) Version() string {
+	return "v1"
+}
+
 func (h ExampleUserCreateHandlerFx) Operation() huma.Operation {
 	return huma.Operation{
 		OperationID:   "api-create-user",
-		Path:          "/api/v1/user",
+		Path:          "/user",
 		Method:        http.MethodPost,
 		Summary:       "Create New User",
 		Description:   "Creates a new user with the provided information and returns the created user's data or an error.",
 		DefaultStatus: http.StatusOK,
 		Tags:          []string{"Users"},
+		Metadata: map[string]any{
+			xhuma.MetadataMiddlewares:   []string{"auth"},
+			xhuma.MetadataAuditResource: "user",
+		},
 		Responses: map[string]*huma.Response{
 			strconv.Itoa(http.StatusOK): {
 				Description: "Successful response",
@@ -136,8 +145,8 @@ func (h ExampleUserCreateHandlerFx) Serve(ctx context.Context, in *ExampleUserCr
 		Age:  in.Body.Age,
 	})
 	if err != nil {
-		h.logger.Error(ctx, "failed to create new user", "input", in, "err", fmt.Sprintf("%+v", err))
-		return nil, huma.Error500InternalServerError("failed to create new user", err)
+		h.logger.Error(ctx, "failed to create new user", "input", in, "errCode", xerror.CodeOf(err), "err", fmt.Sprintf("%+v", err))
+		return nil, xerror.From(err, "failed to create new user")
 	}
 
 	var (
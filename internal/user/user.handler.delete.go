@@ -12,6 +12,7 @@ import (
 	"github.com/rs/xid"
 	"go.uber.org/fx"
 
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
 )
@@ -44,15 +45,23 @@ func (h ExampleUserDeleteHandlerFx) Register(api huma.API) {
 	huma.Register(api, h.Operation(), h.Serve)
 }
 
+func (h ExampleUserDeleteHandlerFx) Version() string {
+	return "v1"
+}
+
 func (h ExampleUserDeleteHandlerFx) Operation() huma.Operation {
 	return huma.Operation{
 		OperationID:   "api-delete-user",
-		Path:          "/api/v1/user/{id}",
+		Path:          "/user/{id}",
 		Method:        http.MethodDelete,
 		Summary:       "Delete User",
 		Description:   "Deletes a specific user identified by their unique ID. Returns a success status if the deletion is successful, or an error if the user does not exist.",
 		DefaultStatus: http.StatusOK,
 		Tags:          []string{"Users"},
+		Metadata: map[string]any{
+			xhuma.MetadataMiddlewares:   []string{"auth"},
+			xhuma.MetadataAuditResource: "user",
+		},
 		Responses: map[string]*huma.Response{
 			strconv.Itoa(http.StatusOK): {
 				Description: "Successful response",
@@ -101,8 +110,8 @@ func (h ExampleUserDeleteHandlerFx) Operation() huma.Operation {
 func (h ExampleUserDeleteHandlerFx) Serve(ctx context.Context, in *ExampleUserDeleteRequestInput) (out *ExampleUserDeleteResponseOutput, err error) {
 	d, err := h.p.ExUserSvc.Delete(ctx, in.ID.String())
 	if err != nil {
-		h.logger.Error(ctx, "failed to delete user", "input", in, "err", fmt.Sprintf("%+v", err))
-		return nil, huma.Error500InternalServerError("failed to delete user", err)
+		h.logger.Error(ctx, "failed to delete user", "input", in, "errCode", xerror.CodeOf(err), "err", fmt.Sprintf("%+v", err))
+		return nil, xerror.From(err, "failed to delete user")
 	}
 
 	var (
@@ -44,15 +44,22 @@ func (h ExampleUserReadAllHandlerFx) Register(api huma.API) {
 	huma.Register(api, h.Operation(), h.Serve)
 }
 
+func (h ExampleUserReadAllHandlerFx) Version() string {
+	return "v1"
+}
+
 func (h ExampleUserReadAllHandlerFx) Operation() huma.Operation {
 	return huma.Operation{
 		OperationID:   "api-read-all-user",
-		Path:          "/api/v1/users",
+		Path:          "/users",
 		Method:        http.MethodGet,
 		Summary:       "Retrieves All Users",
 		Description:   "Retrieves a list of all users.",
 		DefaultStatus: http.StatusOK,
 		Tags:          []string{"Users"},
+		Metadata: map[string]any{
+			xhuma.MetadataTimeout: 15 * time.Second,
+		},
 		Responses: map[string]*huma.Response{
 			strconv.Itoa(http.StatusOK): {
 				Description: "Successful response",
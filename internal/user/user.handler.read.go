@@ -12,6 +12,7 @@ import (
 	"github.com/rs/xid"
 	"go.uber.org/fx"
 
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
 )
@@ -44,10 +45,14 @@ func (h ExampleUserReadHandlerFx) Register(api huma.API) {
 	huma.Register(api, h.Operation(), h.Serve)
 }
 
+func (h ExampleUserReadHandlerFx) Version() string {
+	return "v1"
+}
+
 func (h ExampleUserReadHandlerFx) Operation() huma.Operation {
 	return huma.Operation{
 		OperationID:   "api-read-user",
-		Path:          "/api/v1/user/{id}",
+		Path:          "/user/{id}",
 		Method:        http.MethodGet,
 		Summary:       "Retrieves User Details",
 		Description:   "Retrieves detailed information about a specific user identified by their unique ID. Returns an error if the user does not exist.",
@@ -101,8 +106,8 @@ func (h ExampleUserReadHandlerFx) Operation() huma.Operation {
 func (h ExampleUserReadHandlerFx) Serve(ctx context.Context, in *ExampleUserReadRequestInput) (out *ExampleUserReadResponseOutput, err error) {
 	d, err := h.p.ExUserSvc.Read(ctx, in.ID.String())
 	if err != nil {
-		h.logger.Error(ctx, "failed to read detail user", "input", in, "err", fmt.Sprintf("%+v", err))
-		return nil, huma.Error500InternalServerError("failed to read detail user", err)
+		h.logger.Error(ctx, "failed to read detail user", "input", in, "errCode", xerror.CodeOf(err), "err", fmt.Sprintf("%+v", err))
+		return nil, xerror.From(err, "failed to read detail user")
 	}
 
 	var (
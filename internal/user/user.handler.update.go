@@ -12,6 +12,7 @@ import (
 	"github.com/rs/xid"
 	"go.uber.org/fx"
 
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhuma"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
@@ -45,15 +46,23 @@ func (h ExampleUserUpdateHandlerFx) Register(api huma.API) {
 	huma.Register(api, h.Operation(), h.Serve)
 }
 
+func (h ExampleUserUpdateHandlerFx) Version() string {
+	return "v1"
+}
+
 func (h ExampleUserUpdateHandlerFx) Operation() huma.Operation {
 	return huma.Operation{
 		OperationID:   "api-update-user",
-		Path:          "/api/v1/user/{id}",
+		Path:          "/user/{id}",
 		Method:        http.MethodPut,
 		Summary:       "Update User",
 		Description:   "Updates an existing user's information based on the provided data. Returns the updated user's data or an error if the user is not found or the request is invalid.",
 		DefaultStatus: http.StatusOK,
 		Tags:          []string{"Users"},
+		Metadata: map[string]any{
+			xhuma.MetadataMiddlewares:   []string{"auth"},
+			xhuma.MetadataAuditResource: "user",
+		},
 		Responses: map[string]*huma.Response{
 			strconv.Itoa(http.StatusOK): {
 				Description: "Successful response",
@@ -137,8 +146,8 @@ func (h ExampleUserUpdateHandlerFx) Serve(ctx context.Context, in *ExampleUserUp
 		Age:  in.Body.Age,
 	})
 	if err != nil {
-		h.logger.Error(ctx, "failed to update user", "input", in, "err", fmt.Sprintf("%+v", err))
-		return nil, huma.Error500InternalServerError("failed to update user", err)
+		h.logger.Error(ctx, "failed to update user", "input", in, "errCode", xerror.CodeOf(err), "err", fmt.Sprintf("%+v", err))
+		return nil, xerror.From(err, "failed to update user")
 	}
 
 	var (
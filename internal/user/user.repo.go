@@ -61,7 +61,7 @@ func (r *ExampleUserImplRepoFx) Read(ctx context.Context, id string) (*ExampleUs
 	key := fmt.Sprintf("user:%s", id)
 	data, err := r.p.RDB.Get(ctx, key).Result()
 	if err == redis.Nil {
-		return nil, fmt.Errorf("user with ID %s not found", id)
+		return nil, ErrUserNotFound.Newf("user with ID %s is not found", id)
 	} else if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/fx"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xaudit"
 )
 
 type ExampleUserServiceAPI interface {
@@ -52,6 +54,9 @@ func (s *ExampleUserImplServiceFx) Create(ctx context.Context, user ExampleUser)
 		return nil, err
 	}
 
+	xaudit.SetResource(ctx, "", r.ID.String())
+	xaudit.SetChange(ctx, nil, r)
+
 	return &ExampleUser{
 		ID:        r.ID,
 		Name:      r.Name,
@@ -105,11 +110,22 @@ func (s *ExampleUserImplServiceFx) Update(ctx context.Context, user ExampleUser)
 		UpdatedAt: time.Now(),
 	}
 
+	var before *ExampleUser
+	if xaudit.ScopeFrom(ctx) != nil {
+		b, err := s.p.ExampleUserRepo.Read(ctx, user.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		before = b
+	}
+
 	r, err := s.p.ExampleUserRepo.Update(ctx, d)
 	if err != nil {
 		return nil, err
 	}
 
+	xaudit.SetChange(ctx, before, r)
+
 	return &ExampleUser{
 		ID:        r.ID,
 		Name:      r.Name,
@@ -125,6 +141,8 @@ func (s *ExampleUserImplServiceFx) Delete(ctx context.Context, id string) (*Exam
 		return nil, err
 	}
 
+	xaudit.SetChange(ctx, r, nil)
+
 	return &ExampleUser{
 		ID:        r.ID,
 		Name:      r.Name,
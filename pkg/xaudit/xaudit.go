@@ -0,0 +1,198 @@
+package xaudit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	// AnonymousActor is recorded when the operation is not authenticated.
+	AnonymousActor = "anonymous"
+)
+
+var (
+	ErrQueueFull = errors.New("audit queue is full")
+	ErrClosed    = errors.New("audit writer is closed")
+)
+
+type (
+	// Entry is an append-only audit record of data-changing operation.
+	Entry struct {
+		ID           string
+		Actor        string
+		TenantID     string
+		Action       string
+		ResourceType string
+		ResourceID   string
+		Diff         map[string]Change
+		IP           string
+		UserAgent    string
+		TraceID      string
+		OccurredAt   time.Time
+	}
+
+	// Change is before and after value of a changed field, nil value means the field is absent.
+	Change struct {
+		Before any `json:"before"`
+		After  any `json:"after"`
+	}
+
+	// Recorder records audit entry, i.e: Writer.
+	Recorder interface {
+		Record(ctx context.Context, e Entry) error
+	}
+)
+
+// Scope collects audit entry of an operation, it is stored in the request context by the audit middleware,
+// so the handler and service only declare the actor, the resource and its before and after state.
+type Scope struct {
+	mu sync.Mutex
+
+	actor        string
+	resourceType string
+	resourceID   string
+	before       any
+	after        any
+	skip         bool
+}
+
+type ctxKey struct{}
+
+func WithScope(ctx context.Context, s *Scope) context.Context {
+	return context.WithValue(ctx, ctxKey{}, s)
+}
+
+// ScopeFrom returns the audit scope of the context, it is nil when the operation is not audited.
+func ScopeFrom(ctx context.Context) *Scope {
+	s, _ := ctx.Value(ctxKey{}).(*Scope)
+	return s
+}
+
+// SetActor sets who does the operation, i.e: 'sub' claim of access token.
+func SetActor(ctx context.Context, actor string) {
+	if s := ScopeFrom(ctx); s != nil {
+		s.mu.Lock()
+		s.actor = actor
+		s.mu.Unlock()
+	}
+}
+
+// SetResource overrides resource type and id, by default they are resolved from operation metadata and 'id' path param.
+func SetResource(ctx context.Context, resourceType, resourceID string) {
+	if s := ScopeFrom(ctx); s != nil {
+		s.mu.Lock()
+		if resourceType != "" {
+			s.resourceType = resourceType
+		}
+		s.resourceID = resourceID
+		s.mu.Unlock()
+	}
+}
+
+// SetChange sets the state of the resource before and after the operation, nil before means created
+// and nil after means deleted, the diff is computed from their json representation.
+func SetChange(ctx context.Context, before, after any) {
+	if s := ScopeFrom(ctx); s != nil {
+		s.mu.Lock()
+		s.before, s.after = before, after
+		s.mu.Unlock()
+	}
+}
+
+// Skip marks the operation as not audited, i.e: nothing is changed.
+func Skip(ctx context.Context) {
+	if s := ScopeFrom(ctx); s != nil {
+		s.mu.Lock()
+		s.skip = true
+		s.mu.Unlock()
+	}
+}
+
+// Entry builds audit entry from the scope, the request attributes (i.e: ip and trace id) are filled by the caller.
+func (s *Scope) Entry(resourceType, resourceID string) (Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.skip {
+		return Entry{}, false, nil
+	}
+
+	if s.resourceType != "" {
+		resourceType = s.resourceType
+	}
+	if s.resourceID != "" {
+		resourceID = s.resourceID
+	}
+
+	actor := s.actor
+	if actor == "" {
+		actor = AnonymousActor
+	}
+
+	diff, err := Diff(s.before, s.after)
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	return Entry{
+		Actor:        actor,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Diff:         diff,
+	}, true, nil
+}
+
+// Diff compares top level fields of json representation, unchanged field is omitted.
+func Diff(before, after any) (map[string]Change, error) {
+	b, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]Change)
+	for k, v := range b {
+		if av, ok := a[k]; !ok || !reflect.DeepEqual(v, av) {
+			diff[k] = Change{Before: v, After: av}
+		}
+	}
+
+	for k, v := range a {
+		if _, ok := b[k]; !ok {
+			diff[k] = Change{After: v}
+		}
+	}
+
+	return diff, nil
+}
+
+func fields(v any) (map[string]any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		// non object value is compared as a whole
+		var value any
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return map[string]any{"value": value}, nil
+	}
+
+	return m, nil
+}
@@ -0,0 +1,176 @@
+package xaudit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+const (
+	DefaultBufferSize     = 1024
+	DefaultBatchSize      = 100
+	DefaultFlushInterval  = time.Second
+	DefaultEnqueueTimeout = 5 * time.Second
+	DefaultWriteTimeout   = 30 * time.Second
+)
+
+// Store persists audit entries, it must be append-only.
+type Store interface {
+	Insert(ctx context.Context, entries []Entry) error
+}
+
+type Options struct {
+	// BufferSize is the capacity of pending entries, Record blocks when it is full.
+	BufferSize int
+
+	// BatchSize is the maximum entries per insert.
+	BatchSize int
+
+	// FlushInterval is the maximum time an entry waits in the buffer.
+	FlushInterval time.Duration
+
+	// EnqueueTimeout is the maximum time Record waits for the buffer, ErrQueueFull is returned afterward.
+	EnqueueTimeout time.Duration
+}
+
+// Writer writes audit entries asynchronously in batches, the buffer is bounded so the caller is slowed down
+// (back-pressure) instead of piling up entries when the store is slower than the incoming operations.
+type Writer struct {
+	store Store
+	opts  Options
+	log   xlog.Logger
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan Entry
+	done   chan struct{}
+}
+
+func NewWriter(store Store, opts Options, log xlog.Logger) *Writer {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = DefaultBufferSize
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = DefaultFlushInterval
+	}
+	if opts.EnqueueTimeout <= 0 {
+		opts.EnqueueTimeout = DefaultEnqueueTimeout
+	}
+	if log == nil {
+		log = xlog.NoopLogger
+	}
+
+	return &Writer{
+		store: store,
+		opts:  opts,
+		log:   log,
+		queue: make(chan Entry, opts.BufferSize),
+		done:  make(chan struct{}),
+	}
+}
+
+func (w *Writer) Start() {
+	go w.run()
+}
+
+// Stop rejects new entries and waits until the buffered entries are written.
+func (w *Writer) Stop(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Record enqueues the entry, it blocks while the buffer is full until EnqueueTimeout or the context is done.
+func (w *Writer) Record(ctx context.Context, e Entry) error {
+	if e.ID == "" {
+		e.ID = xid.New().String()
+	}
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return ErrClosed
+	}
+
+	select {
+	case w.queue <- e:
+		return nil
+	default:
+	}
+
+	timer := time.NewTimer(w.opts.EnqueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case w.queue <- e:
+		return nil
+	case <-timer.C:
+		return ErrQueueFull
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Writer) run() {
+	defer close(w.done)
+
+	var (
+		batch  = make([]Entry, 0, w.opts.BatchSize)
+		ticker = time.NewTicker(w.opts.FlushInterval)
+	)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e, ok := <-w.queue:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+
+			batch = append(batch, e)
+			if len(batch) >= w.opts.BatchSize {
+				w.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			w.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (w *Writer) flush(batch []Entry) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultWriteTimeout)
+	defer cancel()
+
+	if err := w.store.Insert(ctx, batch); err != nil {
+		// the entries are kept in the log, so they can be restored manually
+		w.log.Error(ctx, "failed to write audit entries", "auditCount", len(batch), "auditEntries", batch, "err", err)
+	}
+}
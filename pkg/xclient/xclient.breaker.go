@@ -0,0 +1,167 @@
+package xclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultFailureThreshold = 5
+	DefaultSuccessThreshold = 1
+	DefaultOpenTimeout      = 30 * time.Second
+	DefaultHalfOpenRequests = 1
+)
+
+var (
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+)
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+type BreakerConfig struct {
+	Enabled bool
+
+	// FailureThreshold is consecutive failures which open the circuit.
+	FailureThreshold int
+
+	// SuccessThreshold is successful probes which close the half-open circuit.
+	SuccessThreshold int
+
+	// OpenTimeout is how long the circuit rejects requests before it is half-open.
+	OpenTimeout time.Duration
+
+	// HalfOpenRequests is concurrent probes which are allowed while the circuit is half-open.
+	HalfOpenRequests int
+}
+
+// Breaker is a circuit breaker, it opens after consecutive failures, rejects every request with ErrCircuitOpen until
+// OpenTimeout, then lets limited probes through (half-open) which either close or open it again. Nil breaker allows every request.
+type Breaker struct {
+	cfg BreakerConfig
+	now func() time.Time
+
+	mu        sync.Mutex
+	state     State
+	failures  int
+	successes int
+	probes    int
+	openedAt  time.Time
+}
+
+// NewBreaker returns nil when the breaker is disabled.
+func NewBreaker(cfg BreakerConfig) *Breaker {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultFailureThreshold
+	}
+	if cfg.SuccessThreshold <= 0 {
+		cfg.SuccessThreshold = DefaultSuccessThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = DefaultOpenTimeout
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		cfg.HalfOpenRequests = DefaultHalfOpenRequests
+	}
+
+	return &Breaker{cfg: cfg, now: time.Now}
+}
+
+func (b *Breaker) State() State {
+	if b == nil {
+		return StateClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Allow reserves the request, the returned done must be called with the request result.
+func (b *Breaker) Allow() (done func(failed bool), err error) {
+	if b == nil {
+		return func(bool) {}, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen {
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return nil, ErrCircuitOpen
+		}
+		b.transit(StateHalfOpen)
+	}
+
+	state := b.state
+	if state == StateHalfOpen {
+		if b.probes >= b.cfg.HalfOpenRequests {
+			return nil, ErrCircuitOpen
+		}
+		b.probes++
+	}
+
+	return func(failed bool) { b.done(state, failed) }, nil
+}
+
+func (b *Breaker) done(state State, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// the result of request which is allowed in the previous state is stale
+	if state != b.state {
+		return
+	}
+
+	switch b.state {
+	case StateClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		if b.failures++; b.failures >= b.cfg.FailureThreshold {
+			b.transit(StateOpen)
+		}
+	case StateHalfOpen:
+		b.probes--
+		if failed {
+			b.transit(StateOpen)
+			return
+		}
+		if b.successes++; b.successes >= b.cfg.SuccessThreshold {
+			b.transit(StateClosed)
+		}
+	}
+}
+
+func (b *Breaker) transit(state State) {
+	b.state = state
+	b.failures, b.successes, b.probes = 0, 0, 0
+	if state == StateOpen {
+		b.openedAt = b.now()
+	}
+}
@@ -0,0 +1,173 @@
+package xclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreakerNilAllowsEveryRequest(t *testing.T) {
+	b := NewBreaker(BreakerConfig{Enabled: false})
+	if b != nil {
+		t.Fatalf("NewBreaker() = %v, want nil when it is disabled", b)
+	}
+
+	for range 10 {
+		done, err := b.Allow()
+		if err != nil {
+			t.Fatalf("Allow() error = %v, want nil", err)
+		}
+		done(true)
+	}
+
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("State() = %s, want %s", got, StateClosed)
+	}
+}
+
+func TestBreakerTransitions(t *testing.T) {
+	type step struct {
+		// advance moves the clock before the request
+		advance time.Duration
+		// failed is the request result, it is ignored when the request is rejected
+		failed   bool
+		rejected bool
+		state    State
+	}
+
+	cfg := BreakerConfig{
+		Enabled:          true,
+		FailureThreshold: 3,
+		SuccessThreshold: 2,
+		OpenTimeout:      10 * time.Second,
+		HalfOpenRequests: 1,
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "success keeps closed",
+			steps: []step{
+				{failed: false, state: StateClosed},
+				{failed: false, state: StateClosed},
+			},
+		},
+		{
+			name: "consecutive failures open the circuit",
+			steps: []step{
+				{failed: true, state: StateClosed},
+				{failed: true, state: StateClosed},
+				{failed: true, state: StateOpen},
+				{rejected: true, state: StateOpen},
+			},
+		},
+		{
+			name: "success resets the failure count",
+			steps: []step{
+				{failed: true, state: StateClosed},
+				{failed: true, state: StateClosed},
+				{failed: false, state: StateClosed},
+				{failed: true, state: StateClosed},
+				{failed: true, state: StateClosed},
+			},
+		},
+		{
+			name: "successful probes close the circuit after open timeout",
+			steps: []step{
+				{failed: true, state: StateClosed},
+				{failed: true, state: StateClosed},
+				{failed: true, state: StateOpen},
+				{advance: 5 * time.Second, rejected: true, state: StateOpen},
+				{advance: 5 * time.Second, failed: false, state: StateHalfOpen},
+				{failed: false, state: StateClosed},
+			},
+		},
+		{
+			name: "failed probe opens the circuit again",
+			steps: []step{
+				{failed: true, state: StateClosed},
+				{failed: true, state: StateClosed},
+				{failed: true, state: StateOpen},
+				{advance: 10 * time.Second, failed: true, state: StateOpen},
+				{rejected: true, state: StateOpen},
+				{advance: 10 * time.Second, failed: false, state: StateHalfOpen},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				now = time.Unix(0, 0)
+				b   = NewBreaker(cfg)
+			)
+			b.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+
+				done, err := b.Allow()
+				if s.rejected {
+					if !errors.Is(err, ErrCircuitOpen) {
+						t.Fatalf("step %d: Allow() error = %v, want %v", i, err, ErrCircuitOpen)
+					}
+				} else {
+					if err != nil {
+						t.Fatalf("step %d: Allow() error = %v, want nil", i, err)
+					}
+					done(s.failed)
+				}
+
+				if got := b.State(); got != s.state {
+					t.Fatalf("step %d: State() = %s, want %s", i, got, s.state)
+				}
+			}
+		})
+	}
+}
+
+func TestBreakerHalfOpenLimitsProbes(t *testing.T) {
+	var (
+		now = time.Unix(0, 0)
+		b   = NewBreaker(BreakerConfig{Enabled: true, FailureThreshold: 1, OpenTimeout: time.Second, HalfOpenRequests: 2})
+	)
+	b.now = func() time.Time { return now }
+
+	done, _ := b.Allow()
+	done(true)
+
+	now = now.Add(time.Second)
+
+	first, err := b.Allow()
+	if err != nil {
+		t.Fatalf("first probe: Allow() error = %v, want nil", err)
+	}
+	if _, err := b.Allow(); err != nil {
+		t.Fatalf("second probe: Allow() error = %v, want nil", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("third probe: Allow() error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	// the finished probe frees its slot
+	first(false)
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("State() = %s, want %s", got, StateClosed)
+	}
+}
+
+func TestBreakerIgnoresStaleResult(t *testing.T) {
+	b := NewBreaker(BreakerConfig{Enabled: true, FailureThreshold: 1, OpenTimeout: time.Hour})
+
+	stale, _ := b.Allow()
+	done, _ := b.Allow()
+	done(true)
+
+	// the request which is allowed while closed must not touch the open circuit
+	stale(false)
+	if got := b.State(); got != StateOpen {
+		t.Fatalf("State() = %s, want %s", got, StateOpen)
+	}
+}
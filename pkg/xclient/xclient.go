@@ -0,0 +1,195 @@
+package xclient
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+	"resty.dev/v3"
+)
+
+const (
+	DefaultTimeout     = 30 * time.Second
+	DefaultDialTimeout = 10 * time.Second
+)
+
+type (
+	// Config is outgoing http client of an upstream provider.
+	Config struct {
+		Name    string
+		BaseURL string
+		Debug   bool
+
+		// Timeout is the timeout of every attempt, the retry waiting time is not included.
+		Timeout               time.Duration
+		DialTimeout           time.Duration
+		ResponseHeaderTimeout time.Duration
+
+		Retry   RetryConfig
+		Breaker BreakerConfig
+		OAuth2  OAuth2Config
+	}
+
+	// RetryConfig retries idempotent request on temporary transport error (i.e: timeout), 429 and 5xx status except 501
+	// with capped exponential backoff and jitter between WaitTime and MaxWaitTime, the refused connection is not retried.
+	RetryConfig struct {
+		Count       int
+		WaitTime    time.Duration
+		MaxWaitTime time.Duration
+	}
+
+	Options struct {
+		Tracer  trace.Tracer
+		Metrics *Metrics
+
+		// Logger is used by resty debug log, when Config.Debug is enabled.
+		Logger resty.Logger
+
+		// Redis and Locker share the oauth2 token across instances, the token is only cached in memory when they are nil.
+		Redis  *redis.Client
+		Locker *redislock.Client
+	}
+)
+
+// Client is resty client of a provider, its transport is guarded by the circuit breaker
+// and authorized by the oauth2 token source when it is enabled.
+type Client struct {
+	*resty.Client
+
+	Name    string
+	Breaker *Breaker
+	Token   *TokenSource
+}
+
+func New(cfg Config, opts Options) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = DefaultDialTimeout
+	}
+	if opts.Tracer == nil {
+		opts.Tracer = tracenoop.NewTracerProvider().Tracer("")
+	}
+
+	var (
+		breaker = NewBreaker(cfg.Breaker)
+		c       = resty.NewWithTransportSettings(&resty.TransportSettings{
+			DialerTimeout:         cfg.DialTimeout,
+			ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		})
+	)
+
+	var rt http.RoundTripper = &transport{
+		name:    cfg.Name,
+		base:    c.Transport(),
+		breaker: breaker,
+		tracer:  opts.Tracer,
+		metrics: opts.Metrics,
+	}
+
+	var source *TokenSource
+	if cfg.OAuth2.Enabled {
+		// the token endpoint is not guarded by the provider circuit breaker, so token failure does not open it
+		tc := resty.NewWithTransportSettings(&resty.TransportSettings{
+			DialerTimeout:         cfg.DialTimeout,
+			ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		})
+		tc.SetTransport(&transport{
+			name:    cfg.Name + ".oauth2",
+			base:    tc.Transport(),
+			tracer:  opts.Tracer,
+			metrics: opts.Metrics,
+		}).SetTimeout(cfg.Timeout)
+
+		source = NewTokenSource(cfg.Name, cfg.OAuth2, opts.Redis, opts.Locker, tc)
+		rt = &tokenTransport{base: rt, source: source}
+	}
+
+	c.SetTransport(rt)
+
+	c.SetBaseURL(cfg.BaseURL).
+		SetTimeout(cfg.Timeout).
+		SetRetryCount(cfg.Retry.Count).
+		SetRetryWaitTime(cfg.Retry.WaitTime).
+		SetRetryMaxWaitTime(cfg.Retry.MaxWaitTime).
+		SetAllowNonIdempotentRetry(false).
+		AddRetryHooks(func(r *resty.Response, _ error) {
+			if r != nil && r.Request != nil {
+				opts.Metrics.retry(r.Request.Context(), cfg.Name, r.Request.Method)
+			}
+		})
+
+	if cfg.Debug && opts.Logger != nil {
+		c.SetLogger(opts.Logger).
+			EnableDebug().
+			SetDebugLogFormatter(resty.DebugLogJSONFormatter)
+	}
+
+	return &Client{Client: c, Name: cfg.Name, Breaker: breaker, Token: source}
+}
+
+// Clients is registry of provider clients keyed by the provider name.
+type Clients struct {
+	m map[string]*Client
+}
+
+func NewClients(clients ...*Client) *Clients {
+	m := make(map[string]*Client, len(clients))
+	for _, c := range clients {
+		m[c.Name] = c
+	}
+	return &Clients{m}
+}
+
+func (c *Clients) Get(name string) (*Client, error) {
+	if v, ok := c.m[name]; ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("provider client '%s' is not declared in 'provider' config", name)
+}
+
+func (c *Clients) Names() []string {
+	names := make([]string, 0, len(c.m))
+	for k := range c.m {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *Clients) Close() error {
+	for _, v := range c.m {
+		v.Client.Close()
+		if v.Token != nil {
+			v.Token.Close()
+		}
+	}
+	return nil
+}
+
+// Tag is fx name tag of provider client, i.e: `name:"provider:example.one"`.
+func Tag(name string) string {
+	return fmt.Sprintf(`name:"provider:%s"`, name)
+}
+
+// Provide provides the client of the provider as named '*xclient.Client', so it is injected by name:
+//
+//	type Param struct {
+//		fx.In
+//		Client *xclient.Client `name:"provider:example.one"`
+//	}
+func Provide(name string) fx.Option {
+	return fx.Provide(
+		fx.Annotate(
+			func(c *Clients) (*Client, error) { return c.Get(name) },
+			fx.ResultTags(Tag(name)),
+		),
+	)
+}
@@ -0,0 +1,377 @@
+package xclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+	"resty.dev/v3"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xredis"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xsecurity"
+)
+
+const (
+	AuthStyleHeader = "header"
+	AuthStyleBody   = "body"
+
+	DefaultRefreshBefore = 60 * time.Second
+	DefaultLockTimeout   = 10 * time.Second
+
+	lockRetryInterval = 100 * time.Millisecond
+)
+
+var (
+	ErrTokenRequest = errors.New("oauth2 token request is failed")
+)
+
+// OAuth2Config is client credentials grant (RFC 6749 section 4.4) of a provider.
+type OAuth2Config struct {
+	Enabled      bool
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+
+	// AuthStyle is how the client credentials are sent, AuthStyleHeader is basic auth and AuthStyleBody is form params.
+	AuthStyle string
+
+	// RefreshBefore is how long before the expiry the token is refreshed, it is capped to half of the token lifetime.
+	RefreshBefore time.Duration
+
+	// LockTimeout is ttl of the refresh lock, it is also how long an instance waits for the other instance refresh.
+	// The lock is extended while the token is fetched, so it does not expire before the slow token endpoint responds.
+	LockTimeout time.Duration
+
+	// Namespace isolates the cached token, i.e: app env.
+	Namespace string
+}
+
+type Token struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	Expiry      time.Time `json:"expiry"`
+
+	// RefreshAt is when the token is about to expire, it is shared through the cache with the token.
+	RefreshAt time.Time `json:"refresh_at"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// TokenSource fetches client credentials token of a provider, the token is cached in redis so it is shared by every instance,
+// and it is refreshed under redis lock, so only one instance requests the token endpoint at a time.
+// Without redis, the token is only cached in memory.
+type TokenSource struct {
+	name   string
+	cfg    OAuth2Config
+	key    string
+	rdb    *redis.Client
+	locker *redislock.Client
+	client *resty.Client
+	now    func() time.Time
+	group  singleflight.Group
+
+	mu    sync.Mutex
+	token *Token
+}
+
+func NewTokenSource(name string, cfg OAuth2Config, rdb *redis.Client, locker *redislock.Client, client *resty.Client) *TokenSource {
+	if cfg.AuthStyle == "" {
+		cfg.AuthStyle = AuthStyleHeader
+	}
+	if cfg.RefreshBefore <= 0 {
+		cfg.RefreshBefore = DefaultRefreshBefore
+	}
+	if cfg.LockTimeout <= 0 {
+		cfg.LockTimeout = DefaultLockTimeout
+	}
+
+	// the credentials are part of the key, so token of the previous credentials is not reused after rotation
+	hash := xsecurity.HexHashSHA256(fmt.Sprintf("%s|%s|%s|%s", cfg.TokenURL, cfg.ClientID, cfg.ClientSecret, strings.Join(cfg.Scopes, " ")))
+
+	return &TokenSource{
+		name:   name,
+		cfg:    cfg,
+		key:    fmt.Sprintf("oauth2:%s:provider:%s:token:%s", cfg.Namespace, name, hash[:16]),
+		rdb:    rdb,
+		locker: locker,
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Token returns the cached token, it is fetched when it is missing or about to expire.
+// The concurrent callers share one fetch, and every caller stops waiting when its context is done.
+func (s *TokenSource) Token(ctx context.Context) (*Token, error) {
+	if t := s.cached(); s.fresh(t) {
+		return t, nil
+	}
+
+	// the shared fetch is not canceled with the caller which starts it, it is bounded by the lock and request timeout
+	ch := s.group.DoChan(s.key, func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+
+		t := s.load(ctx)
+		if !s.fresh(t) {
+			var err error
+			if t, err = s.refresh(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		s.mu.Lock()
+		s.token = t
+		s.mu.Unlock()
+
+		return t, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-ch:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*Token), nil
+	}
+}
+
+func (s *TokenSource) cached() *Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token
+}
+
+// Invalidate drops the token which is rejected by the provider, the cached token is kept when it is already refreshed.
+func (s *TokenSource) Invalidate(ctx context.Context, token *Token) {
+	s.mu.Lock()
+	if s.token != nil && s.token.AccessToken == token.AccessToken {
+		s.token = nil
+	}
+	s.mu.Unlock()
+
+	if s.rdb != nil {
+		_ = invalidateTokenScript.Run(ctx, s.rdb, []string{s.key}, token.AccessToken).Err()
+	}
+}
+
+func (s *TokenSource) Close() error {
+	return s.client.Close()
+}
+
+func (s *TokenSource) refresh(ctx context.Context) (*Token, error) {
+	if s.locker != nil {
+		lctx, cancel := context.WithTimeout(ctx, s.cfg.LockTimeout)
+		lock, err := s.locker.Obtain(lctx, s.key+":lock", s.cfg.LockTimeout, &redislock.Options{
+			RetryStrategy: redislock.LinearBackoff(lockRetryInterval),
+		})
+		cancel()
+
+		switch {
+		case err == nil:
+			defer lock.Release(context.WithoutCancel(ctx))
+			defer xredis.KeepLock(ctx, lock, s.cfg.LockTimeout, nil)()
+
+			// the other instance may refresh the token while this instance is waiting for the lock
+			if t := s.load(ctx); s.fresh(t) {
+				return t, nil
+			}
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		case errors.Is(err, redislock.ErrNotObtained), errors.Is(err, context.DeadlineExceeded):
+			// the other instance is still refreshing, the token which is not expired yet is still usable
+			if t := s.load(ctx); s.valid(t) {
+				return t, nil
+			}
+		}
+
+		// the token is fetched without the lock when redis is unavailable or the lock is not released in time,
+		// so the provider is still reachable
+	}
+
+	t, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.store(ctx, t)
+	return t, nil
+}
+
+func (s *TokenSource) fetch(ctx context.Context) (*Token, error) {
+	var (
+		res  tokenResponse
+		form = map[string]string{"grant_type": "client_credentials"}
+		req  = s.client.R().SetContext(ctx).SetResult(&res)
+	)
+
+	if len(s.cfg.Scopes) > 0 {
+		form["scope"] = strings.Join(s.cfg.Scopes, " ")
+	}
+
+	if s.cfg.AuthStyle == AuthStyleBody {
+		form["client_id"], form["client_secret"] = s.cfg.ClientID, s.cfg.ClientSecret
+	} else {
+		// the credentials are form url encoded before basic auth encoding, see RFC 6749 section 2.3.1
+		req.SetBasicAuth(url.QueryEscape(s.cfg.ClientID), url.QueryEscape(s.cfg.ClientSecret))
+	}
+
+	resp, err := req.SetFormData(form).Post(s.cfg.TokenURL)
+	if err != nil {
+		return nil, fmt.Errorf("provider '%s': %w: %w", s.name, ErrTokenRequest, err)
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("provider '%s': %w: status %d: %s", s.name, ErrTokenRequest, resp.StatusCode(), resp.String())
+	}
+	if res.AccessToken == "" {
+		return nil, fmt.Errorf("provider '%s': %w: access token is empty", s.name, ErrTokenRequest)
+	}
+
+	t := Token{AccessToken: res.AccessToken, TokenType: res.TokenType}
+	if res.ExpiresIn > 0 {
+		// the refresh margin is capped to half of the lifetime, so the short-lived token is still reused before it is refreshed
+		lifetime := time.Duration(res.ExpiresIn) * time.Second
+		t.Expiry = s.now().Add(lifetime)
+		t.RefreshAt = t.Expiry.Add(-min(s.cfg.RefreshBefore, lifetime/2))
+	}
+
+	return &t, nil
+}
+
+func (s *TokenSource) load(ctx context.Context) *Token {
+	if s.rdb == nil {
+		return nil
+	}
+
+	b, err := s.rdb.Get(ctx, s.key).Bytes()
+	if err != nil {
+		return nil
+	}
+
+	var t Token
+	if err := json.Unmarshal(b, &t); err != nil || t.AccessToken == "" {
+		return nil
+	}
+
+	return &t
+}
+
+// store caches the token until it is expired, the failure is ignored since the token is still cached in memory.
+func (s *TokenSource) store(ctx context.Context, t *Token) {
+	if s.rdb == nil {
+		return
+	}
+
+	var ttl time.Duration
+	if !t.Expiry.IsZero() {
+		if ttl = t.Expiry.Sub(s.now()); ttl <= 0 {
+			return
+		}
+	}
+
+	b, err := json.Marshal(t)
+	if err != nil {
+		return
+	}
+
+	_ = s.rdb.Set(ctx, s.key, b, ttl).Err()
+}
+
+// valid reports the token is not expired, the token without expiry is valid until it is rejected by the provider.
+func (s *TokenSource) valid(t *Token) bool {
+	return t != nil && (t.Expiry.IsZero() || s.now().Before(t.Expiry))
+}
+
+// fresh reports the token is not about to expire.
+func (s *TokenSource) fresh(t *Token) bool {
+	return t != nil && (t.Expiry.IsZero() || s.now().Before(t.RefreshAt))
+}
+
+// invalidateTokenScript deletes the cached token only when it is the rejected token.
+var invalidateTokenScript = redis.NewScript(`
+local raw = redis.call("GET", KEYS[1])
+if not raw then
+	return 0
+end
+
+local ok, token = pcall(cjson.decode, raw)
+if ok and token["access_token"] == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+
+return 0
+`)
+
+// tokenTransport authorizes every attempt with the provider token, the request which is rejected with 401
+// is sent once more with a new token, unless its body can not be replayed.
+type tokenTransport struct {
+	base   http.RoundTripper
+	source *TokenSource
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the caller authorization is kept
+	if req.Header.Get("Authorization") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+
+	token, err := t.source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(authorize(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	t.source.Invalidate(ctx, token)
+
+	retry := authorize(req, nil)
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+
+	if token, err = t.source.Token(ctx); err != nil {
+		if retry.Body != nil {
+			retry.Body.Close()
+		}
+		return resp, nil
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	return t.base.RoundTrip(authorize(retry, token))
+}
+
+// authorize sets the token on the request copy, the request must not be modified by round tripper.
+func authorize(req *http.Request, token *Token) *http.Request {
+	r := req.Clone(req.Context())
+	if token != nil {
+		r.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	}
+	return r
+}
@@ -0,0 +1,217 @@
+package xclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"resty.dev/v3"
+)
+
+type tokenServer struct {
+	*httptest.Server
+
+	fetches atomic.Int32
+	release chan struct{}
+}
+
+// newTokenServer answers every token request with 'token-<n>' which is expired after expiresIn seconds,
+// the response waits for release when it is not nil.
+func newTokenServer(t *testing.T, status int, expiresIn int, release chan struct{}) *tokenServer {
+	t.Helper()
+
+	s := &tokenServer{release: release}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := s.fetches.Add(1)
+		if s.release != nil {
+			<-s.release
+		}
+
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func newTestTokenSource(t *testing.T, url string, cfg OAuth2Config) *TokenSource {
+	t.Helper()
+
+	cfg.TokenURL, cfg.ClientID, cfg.ClientSecret = url, "id", "secret"
+	s := NewTokenSource("test", cfg, nil, nil, resty.New())
+	t.Cleanup(func() { s.Close() })
+
+	return s
+}
+
+func TestTokenSourceRefreshMargin(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		expiresIn     int
+		refreshBefore time.Duration
+		refreshAt     time.Time
+	}{
+		{
+			name:          "margin is shorter than half of the lifetime",
+			expiresIn:     3600,
+			refreshBefore: time.Minute,
+			refreshAt:     now.Add(59 * time.Minute),
+		},
+		{
+			name:          "margin is capped to half of the lifetime",
+			expiresIn:     60,
+			refreshBefore: time.Minute,
+			refreshAt:     now.Add(30 * time.Second),
+		},
+		{
+			name:      "default margin is capped",
+			expiresIn: 30,
+			refreshAt: now.Add(15 * time.Second),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				srv   = newTokenServer(t, http.StatusOK, tt.expiresIn, nil)
+				s     = newTestTokenSource(t, srv.URL, OAuth2Config{RefreshBefore: tt.refreshBefore})
+				clock = now
+			)
+			s.now = func() time.Time { return clock }
+
+			tok, err := s.Token(context.Background())
+			if err != nil {
+				t.Fatalf("Token() error = %v", err)
+			}
+
+			if want := now.Add(time.Duration(tt.expiresIn) * time.Second); !tok.Expiry.Equal(want) {
+				t.Errorf("Expiry = %s, want %s", tok.Expiry, want)
+			}
+			if !tok.RefreshAt.Equal(tt.refreshAt) {
+				t.Errorf("RefreshAt = %s, want %s", tok.RefreshAt, tt.refreshAt)
+			}
+
+			// the token is reused until it is about to expire
+			if _, err := s.Token(context.Background()); err != nil {
+				t.Fatalf("Token() error = %v", err)
+			}
+			if got := srv.fetches.Load(); got != 1 {
+				t.Fatalf("fetches = %d, want 1 before refresh time", got)
+			}
+
+			clock = tt.refreshAt
+			if _, err := s.Token(context.Background()); err != nil {
+				t.Fatalf("Token() error = %v", err)
+			}
+			if got := srv.fetches.Load(); got != 2 {
+				t.Fatalf("fetches = %d, want 2 at refresh time", got)
+			}
+		})
+	}
+}
+
+func TestTokenSourceSharesFetch(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		srv     = newTokenServer(t, http.StatusOK, 3600, release)
+		s       = newTestTokenSource(t, srv.URL, OAuth2Config{})
+
+		wg     sync.WaitGroup
+		tokens = make([]string, 5)
+	)
+
+	for i := range tokens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if tok, err := s.Token(context.Background()); err == nil {
+				tokens[i] = tok.AccessToken
+			}
+		}()
+	}
+
+	// the caller which gives up does not cancel the shared fetch
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := s.Token(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Token() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if got := srv.fetches.Load(); got != 1 {
+		t.Fatalf("fetches = %d, want 1", got)
+	}
+	for i, tok := range tokens {
+		if tok != "token-1" {
+			t.Fatalf("caller %d token = %q, want %q", i, tok, "token-1")
+		}
+	}
+}
+
+func TestTokenSourceInvalidate(t *testing.T) {
+	var (
+		srv = newTokenServer(t, http.StatusOK, 3600, nil)
+		s   = newTestTokenSource(t, srv.URL, OAuth2Config{})
+		ctx = context.Background()
+	)
+
+	first, err := s.Token(ctx)
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+
+	// the stale token does not drop the refreshed token
+	s.Invalidate(ctx, &Token{AccessToken: "token-0"})
+	if tok, _ := s.Token(ctx); tok.AccessToken != first.AccessToken {
+		t.Fatalf("Token() = %q, want %q", tok.AccessToken, first.AccessToken)
+	}
+
+	s.Invalidate(ctx, first)
+	tok, err := s.Token(ctx)
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if tok.AccessToken != "token-2" {
+		t.Fatalf("Token() = %q, want %q", tok.AccessToken, "token-2")
+	}
+}
+
+func TestTokenSourceFetchError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{name: "client error", status: http.StatusUnauthorized},
+		{name: "server error", status: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				srv = newTokenServer(t, tt.status, 3600, nil)
+				s   = newTestTokenSource(t, srv.URL, OAuth2Config{})
+			)
+
+			if _, err := s.Token(context.Background()); !errors.Is(err, ErrTokenRequest) {
+				t.Fatalf("Token() error = %v, want %v", err, ErrTokenRequest)
+			}
+		})
+	}
+}
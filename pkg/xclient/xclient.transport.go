@@ -0,0 +1,163 @@
+package xclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xtracer"
+)
+
+// Metrics records RED (rate, errors, duration) metrics of outgoing requests per provider.
+type Metrics struct {
+	requests metric.Int64Counter
+	failures metric.Int64Counter
+	retries  metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func NewMetrics(meter metric.Meter) (*Metrics, error) {
+	var m Metrics
+
+	requests, err := meter.Int64Counter(
+		"http.client.request.count",
+		metric.WithDescription("Number of outgoing request per provider, method and status"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := meter.Int64Counter(
+		"http.client.request.errors",
+		metric.WithDescription("Number of failed outgoing request (transport error, rejected by circuit breaker or server error status) per provider, method and status"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	retries, err := meter.Int64Counter(
+		"http.client.request.retries",
+		metric.WithDescription("Number of retried outgoing request per provider and method"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram(
+		"http.client.request.duration",
+		metric.WithDescription("Duration of outgoing request attempt per provider, method and status"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.requests, m.failures, m.retries, m.duration = requests, failures, retries, duration
+	return &m, nil
+}
+
+func (m *Metrics) record(ctx context.Context, provider, method, status string, start time.Time, failed bool) {
+	if m == nil {
+		return
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("provider", provider),
+		attribute.String("http.request.method", method),
+		attribute.String("http.response.status_code", status),
+	)
+
+	m.requests.Add(ctx, 1, attrs)
+	m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+	if failed {
+		m.failures.Add(ctx, 1, attrs)
+	}
+}
+
+func (m *Metrics) retry(ctx context.Context, provider, method string) {
+	if m == nil {
+		return
+	}
+
+	m.retries.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("provider", provider),
+		attribute.String("http.request.method", method),
+	))
+}
+
+// transport wraps every attempt, including retries, with the circuit breaker, client span, trace propagation and metrics.
+type transport struct {
+	name    string
+	base    http.RoundTripper
+	breaker *Breaker
+	tracer  trace.Tracer
+	metrics *Metrics
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var (
+		ctx   = req.Context()
+		start = time.Now()
+	)
+
+	done, err := t.breaker.Allow()
+	if err != nil {
+		t.metrics.record(ctx, t.name, req.Method, "rejected", start, true)
+		return nil, fmt.Errorf("provider '%s': %w", t.name, err)
+	}
+
+	ctx, span := t.tracer.Start(ctx, "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("provider", t.name),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	// the request must not be modified by round tripper, so the propagated headers are set on its copy
+	req = req.WithContext(ctx)
+	req.Header = req.Header.Clone()
+	xtracer.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		// the request canceled by the caller says nothing about the provider, so it is not the failure of breaker
+		var (
+			canceled = errors.Is(ctx.Err(), context.Canceled) || errors.Is(err, context.Canceled)
+			status   = "error"
+		)
+		if canceled {
+			status = "canceled"
+		}
+
+		done(!canceled)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		t.metrics.record(ctx, t.name, req.Method, status, start, !canceled)
+		return nil, err
+	}
+
+	failed := resp.StatusCode >= http.StatusInternalServerError
+	done(failed)
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if failed {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	t.metrics.record(ctx, t.name, req.Method, strconv.Itoa(resp.StatusCode), start, failed)
+
+	return resp, nil
+}
@@ -0,0 +1,46 @@
+package xerror
+
+import "net/http"
+
+// Generic errors, they are used for error which is not catalogued by its http status.
+var (
+	ErrBadRequest           = defineStatus(http.StatusBadRequest, "bad request")
+	ErrUnauthorized         = defineStatus(http.StatusUnauthorized, "unauthorized")
+	ErrForbidden            = defineStatus(http.StatusForbidden, "forbidden")
+	ErrNotFound             = defineStatus(http.StatusNotFound, "resource is not found")
+	ErrMethodNotAllowed     = defineStatus(http.StatusMethodNotAllowed, "method is not allowed")
+	ErrNotAcceptable        = defineStatus(http.StatusNotAcceptable, "not acceptable")
+	ErrRequestTimeout       = defineStatus(http.StatusRequestTimeout, "request timeout")
+	ErrConflict             = defineStatus(http.StatusConflict, "conflict")
+	ErrPreconditionFailed   = defineStatus(http.StatusPreconditionFailed, "precondition failed")
+	ErrRequestTooLarge      = defineStatus(http.StatusRequestEntityTooLarge, "request entity is too large")
+	ErrUnsupportedMediaType = defineStatus(http.StatusUnsupportedMediaType, "unsupported media type")
+	ErrUnprocessableEntity  = defineStatus(http.StatusUnprocessableEntity, "unprocessable entity")
+	ErrTooManyRequests      = defineStatus(http.StatusTooManyRequests, "too many requests")
+	ErrInternal             = defineStatus(http.StatusInternalServerError, "internal server error")
+	ErrNotImplemented       = defineStatus(http.StatusNotImplemented, "not implemented")
+	ErrBadGateway           = defineStatus(http.StatusBadGateway, "bad gateway")
+	ErrServiceUnavailable   = defineStatus(http.StatusServiceUnavailable, "service is unavailable")
+	ErrGatewayTimeout       = defineStatus(http.StatusGatewayTimeout, "gateway timeout")
+)
+
+// Platform errors, they are sent by global and operation middlewares.
+var (
+	ErrPanic                 = Define("PANIC", http.StatusInternalServerError, "panic error")
+	ErrOperationTimeout      = Define("OPERATION_TIMEOUT", http.StatusGatewayTimeout, "operation timeout")
+	ErrRateLimitExceeded     = Define("RATE_LIMIT_EXCEEDED", http.StatusTooManyRequests, "rate limit exceeded")
+	ErrServerOverloaded      = Define("SERVER_OVERLOADED", http.StatusServiceUnavailable, "server is overloaded")
+	ErrTenantRequired        = Define("TENANT_REQUIRED", http.StatusBadRequest, "tenant is required")
+	ErrTenantMismatch        = Define("TENANT_MISMATCH", http.StatusForbidden, "tenant mismatch")
+	ErrIdempotencyInProgress = Define("IDEMPOTENCY_KEY_IN_PROGRESS", http.StatusConflict, "idempotency key in progress")
+	ErrIdempotencyMismatch   = Define("IDEMPOTENCY_KEY_MISMATCH", http.StatusUnprocessableEntity, "idempotency key payload mismatch")
+	ErrInvalidToken          = Define("INVALID_TOKEN", http.StatusUnauthorized, "invalid or missing access token")
+)
+
+var generic = map[int]*Definition{}
+
+func defineStatus(status int, title string) *Definition {
+	d := Define(statusCode(status), status, title)
+	generic[status] = d
+	return d
+}
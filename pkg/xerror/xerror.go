@@ -0,0 +1,227 @@
+package xerror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+// Definition is an entry of the error catalog, its code is stable machine-readable identifier which is sent
+// as 'err.code' of error response and logged as 'errCode', so it must never be changed once released.
+//
+// Definition is also an error, so it is used as sentinel, i.e: errors.Is(err, user.ErrUserNotFound).
+type Definition struct {
+	Code   string `json:"code"`
+	Status int    `json:"status"`
+	Title  string `json:"title"`
+}
+
+var (
+	catalogMu sync.RWMutex
+	catalog   = make(map[string]*Definition)
+)
+
+// Define registers the definition into the catalog, it must be called once per code as package variable.
+func Define(code string, status int, title string) *Definition {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	if _, ok := catalog[code]; ok {
+		panic(fmt.Sprintf("xerror: error code '%s' is defined more than once", code))
+	}
+
+	d := &Definition{Code: code, Status: status, Title: title}
+	catalog[code] = d
+	return d
+}
+
+// Catalog returns every defined error sorted by code, it is exported into the openapi document.
+func Catalog() []Definition {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	defs := make([]Definition, 0, len(catalog))
+	for _, d := range catalog {
+		defs = append(defs, *d)
+	}
+
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Code < defs[j].Code
+	})
+
+	return defs
+}
+
+// Lookup returns the definition of the code.
+func Lookup(code string) (*Definition, bool) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	d, ok := catalog[code]
+	return d, ok
+}
+
+// StatusDefinition returns the generic definition of http status, i.e: 404 is 'NOT_FOUND', it is used
+// for error which is not catalogued, i.e: request validation and unknown route.
+func StatusDefinition(status int) *Definition {
+	if d, ok := generic[status]; ok {
+		return d
+	}
+
+	if status < http.StatusBadRequest {
+		return ErrInternal
+	}
+
+	return &Definition{Code: statusCode(status), Status: status, Title: strings.ToLower(http.StatusText(status))}
+}
+
+func (d *Definition) Error() string {
+	return d.Code
+}
+
+// New creates error occurrence, the detail is sent as 'err.detail' of error response.
+func (d *Definition) New(detail string) *Error {
+	return &Error{def: d, Detail: detail}
+}
+
+func (d *Definition) Newf(format string, args ...any) *Error {
+	return d.New(fmt.Sprintf(format, args...))
+}
+
+// Wrap creates error occurrence which keeps the cause, the cause is logged and it is only sent as error detail of client error.
+func (d *Definition) Wrap(cause error, detail string) *Error {
+	return &Error{def: d, Detail: detail, Cause: cause}
+}
+
+func (d *Definition) Wrapf(cause error, format string, args ...any) *Error {
+	return d.Wrap(cause, fmt.Sprintf(format, args...))
+}
+
+// Error is an occurrence of catalogued error.
+type Error struct {
+	def *Definition
+
+	Detail string
+	Cause  error
+}
+
+func (e *Error) Error() string {
+	msg := e.def.Code
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is matches the definition of the error, so errors.Is(err, ErrNotFound) is true for any ErrNotFound occurrence.
+func (e *Error) Is(target error) bool {
+	switch t := target.(type) {
+	case *Definition:
+		return t == e.def
+	case *Error:
+		return t.def == e.def
+	}
+	return false
+}
+
+func (e *Error) Definition() *Definition {
+	return e.def
+}
+
+func (e *Error) Code() string {
+	return e.def.Code
+}
+
+func (e *Error) Status() int {
+	return e.def.Status
+}
+
+// Response converts the error into error response body which is localized into the locale of the context,
+// the cause is only included as error detail of client error (4xx), so internal failure is never leaked to the client.
+func (e *Error) Response(ctx context.Context, details ...*huma.ErrorDetail) *xresp.GeneralResponseError {
+	detail := e.Detail
+	if detail == "" {
+		detail = e.def.Title
+	}
+
+	if e.Cause != nil && e.def.Status < http.StatusInternalServerError {
+		details = append([]*huma.ErrorDetail{{Message: e.Cause.Error()}}, details...)
+	}
+
+	return Localize(ctx, &xresp.GeneralResponseError{
+		Code: e.def.Status,
+		Msg:  http.StatusText(e.def.Status),
+		Err: &xresp.ErrorModel{
+			Code:   e.def.Code,
+			Title:  e.def.Title,
+			Detail: detail,
+			Errors: details,
+		},
+		TraceID: xlog.GetReqTraceID(ctx),
+	})
+}
+
+// As returns the first catalogued error of the chain, a bare definition is treated as an occurrence without detail.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+
+	var d *Definition
+	if errors.As(err, &d) {
+		return d.New(""), true
+	}
+
+	return nil, false
+}
+
+// CodeOf returns the code of catalogued error, otherwise empty string.
+func CodeOf(err error) string {
+	if e, ok := As(err); ok {
+		return e.Code()
+	}
+	return ""
+}
+
+// From returns the catalogued error of the chain as is, otherwise it wraps the error as ErrInternal with the detail.
+func From(err error, detail string) *Error {
+	if e, ok := As(err); ok {
+		return e
+	}
+	return ErrInternal.Wrap(err, detail)
+}
+
+// statusCode turns status text into code, i.e: "Request Entity Too Large" is 'REQUEST_ENTITY_TOO_LARGE'.
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return fmt.Sprintf("HTTP_%d", status)
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, strings.ReplaceAll(text, "'", ""))
+}
@@ -0,0 +1,48 @@
+package xerror
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xi18n"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+)
+
+// Localize translates error response into the locale of the context (xi18n.WithLocale) using the default bundle.
+// The message is translated by 'http.status.<status>' key, the title by 'error.<CODE>' key and the detail
+// including error details by matching SourceLocale messages, untranslated message is kept as is.
+func Localize(ctx context.Context, r *xresp.GeneralResponseError) *xresp.GeneralResponseError {
+	var (
+		b      = xi18n.Default()
+		locale = xi18n.LocaleFrom(ctx)
+	)
+
+	if b == nil || locale == "" || r == nil {
+		return r
+	}
+
+	if status, ok := r.Code.(int); ok {
+		if msg, ok := b.Message(locale, "http.status."+strconv.Itoa(status)); ok {
+			r.Msg = msg
+		}
+	}
+
+	if r.Err == nil {
+		return r
+	}
+
+	if r.Err.Code != "" {
+		if title, ok := b.Message(locale, "error."+r.Err.Code); ok {
+			r.Err.Title = title
+		}
+	}
+
+	r.Err.Detail = b.Translate(locale, r.Err.Detail)
+	for _, d := range r.Err.Errors {
+		if d != nil {
+			d.Message = b.Translate(locale, d.Message)
+		}
+	}
+
+	return r
+}
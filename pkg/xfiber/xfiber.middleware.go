@@ -16,3 +16,75 @@ func SkipPath(c *fiber.Ctx, ps ...string) (next func() error, skip bool) {
 
 	return nil, false
 }
+
+func BearerToken(c *fiber.Ctx) (token string, ok bool) {
+	auth := c.Get(fiber.HeaderAuthorization)
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	return token, token != ""
+}
+
+// MatchPath matches request path against huma or fiber style pattern,
+// path params ('{id}' or ':id') match a single segment and trailing '*' matches the rest, i.e:
+//
+//	MatchPath("/api/v1/user/{id}", "/api/v1/user/123") // true
+//	MatchPath("/api/v1/*", "/api/v1/user/123")         // true
+func MatchPath(pattern, path string) bool {
+	return CompilePath(pattern).Match(path)
+}
+
+// MatchOperation returns the most specific "METHOD path" pattern key which matches the request and its value, i.e:
+//
+//	MatchOperation(map[string]int{"GET /api/v1/*": 1, "GET /api/v1/user/{id}": 2}, "GET", "/api/v1/user/123") // "GET /api/v1/user/{id}", 2, true
+//
+// The longer path pattern is more specific, the tie is broken by the key, so the result is stable.
+func MatchOperation[V any](patterns map[string]V, method, path string) (key string, value V, ok bool) {
+	size := -1
+	for k, v := range patterns {
+		m, p, found := strings.Cut(k, " ")
+		if p = strings.TrimSpace(p); !found || !strings.EqualFold(m, method) || !MatchPath(p, path) {
+			continue
+		}
+
+		if len(p) < size || (len(p) == size && k > key) {
+			continue
+		}
+
+		key, value, size = k, v, len(p)
+	}
+
+	return key, value, size >= 0
+}
+
+// BufferedBody returns raw request body, it returns nil for streamed request body,
+// because reading it buffers the whole stream into memory before the handler consumes it.
+func BufferedBody(c *fiber.Ctx) []byte {
+	if c.Request().IsBodyStream() {
+		return nil
+	}
+	return c.BodyRaw()
+}
+
+// HeaderCarrier adapts request headers into otel propagation.TextMapCarrier.
+type HeaderCarrier struct {
+	C *fiber.Ctx
+}
+
+func (h HeaderCarrier) Get(key string) string {
+	return h.C.Get(key)
+}
+
+func (h HeaderCarrier) Set(key, value string) {
+	h.C.Request().Header.Set(key, value)
+}
+
+func (h HeaderCarrier) Keys() []string {
+	keys := make([]string, 0)
+	h.C.Request().Header.VisitAll(func(k, _ []byte) {
+		keys = append(keys, string(k))
+	})
+	return keys
+}
@@ -0,0 +1,66 @@
+package xfiber
+
+import "testing"
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "/api/v1/user", path: "/api/v1/user", want: true},
+		{pattern: "/api/v1/user", path: "/api/v1/users", want: false},
+		{pattern: "/api/v1/user/{id}", path: "/api/v1/user/123", want: true},
+		{pattern: "/api/v1/user/:id", path: "/api/v1/user/123", want: true},
+		{pattern: "/api/v1/user/{id}", path: "/api/v1/user/123/avatar", want: false},
+		{pattern: "/api/v1/*", path: "/api/v1/user/123", want: true},
+		{pattern: "/api/v1/*", path: "/api/v2/user", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			if got := MatchPath(tt.pattern, tt.path); got != tt.want {
+				t.Fatalf("MatchPath(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchOperation(t *testing.T) {
+	patterns := map[string]int{
+		"GET /api/v1/*":          1,
+		"GET /api/v1/user/{id}":  2,
+		"POST /api/v1/user":      3,
+		"get /api/v1/report/*":   4,
+		"GET /api/v1/report/:id": 5,
+		"/api/v1/invalid":        6,
+		"GET /api/v1/tag/{name}": 7,
+		"GET /api/v1/tag/:slugs": 8,
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		key    string
+		value  int
+		ok     bool
+	}{
+		{name: "longer pattern wins", method: "GET", path: "/api/v1/user/1", key: "GET /api/v1/user/{id}", value: 2, ok: true},
+		{name: "wildcard", method: "GET", path: "/api/v1/order/1", key: "GET /api/v1/*", value: 1, ok: true},
+		{name: "method is case-insensitive", method: "post", path: "/api/v1/user", key: "POST /api/v1/user", value: 3, ok: true},
+		{name: "fiber param pattern", method: "GET", path: "/api/v1/report/1", key: "GET /api/v1/report/:id", value: 5, ok: true},
+		{name: "tie is broken by key", method: "GET", path: "/api/v1/tag/go", key: "GET /api/v1/tag/:slugs", value: 8, ok: true},
+		{name: "method does not match", method: "DELETE", path: "/api/v1/user/1"},
+		{name: "key without method is ignored", method: "GET", path: "/api/v2/invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, value, ok := MatchOperation(patterns, tt.method, tt.path)
+			if key != tt.key || value != tt.value || ok != tt.ok {
+				t.Fatalf("MatchOperation(%q, %q) = %q, %d, %v, want %q, %d, %v", tt.method, tt.path, key, value, ok, tt.key, tt.value, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package xfiber
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RouteMatcher resolves request path into registered route template (i.e: '/api/v1/user/:id'),
+// so global middleware can keep per route state without high cardinality keys.
+//
+// Routes are loaded lazily on the first Match call, because global middleware
+// is attached before any handler is registered into the fiber app.
+type RouteMatcher struct {
+	app    *fiber.App
+	once   sync.Once
+	routes map[string][]PathPattern
+}
+
+func NewRouteMatcher(app *fiber.App) *RouteMatcher {
+	return &RouteMatcher{app: app}
+}
+
+func (m *RouteMatcher) Match(method, path string) (string, bool) {
+	if m == nil || m.app == nil {
+		return "", false
+	}
+
+	m.once.Do(func() {
+		m.routes = make(map[string][]PathPattern)
+		for _, r := range m.app.GetRoutes(true) {
+			m.routes[r.Method] = append(m.routes[r.Method], CompilePath(r.Path))
+		}
+	})
+
+	// follow fiber behavior, the first registered route wins
+	for _, p := range m.routes[method] {
+		if p.Match(path) {
+			return p.Pattern, true
+		}
+	}
+
+	return "", false
+}
+
+// PathPattern is compiled MatchPath pattern, so the pattern is not parsed on every request.
+type PathPattern struct {
+	Pattern string
+
+	prefix   string
+	wildcard bool
+	segments []pathSegment
+}
+
+type pathSegment struct {
+	value string
+	param bool
+}
+
+func CompilePath(pattern string) PathPattern {
+	if rest, ok := strings.CutSuffix(pattern, "*"); ok {
+		return PathPattern{Pattern: pattern, prefix: rest, wildcard: true}
+	}
+
+	var (
+		parts    = strings.Split(strings.Trim(pattern, "/"), "/")
+		segments = make([]pathSegment, len(parts))
+	)
+
+	for i, p := range parts {
+		segments[i] = pathSegment{
+			value: p,
+			param: strings.HasPrefix(p, ":") || (strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}")),
+		}
+	}
+
+	return PathPattern{Pattern: pattern, segments: segments}
+}
+
+// Match walks the request path segments without allocation.
+func (p PathPattern) Match(path string) bool {
+	if p.wildcard {
+		return strings.HasPrefix(path, p.prefix)
+	}
+
+	rest := strings.Trim(path, "/")
+	for i, seg := range p.segments {
+		v, next, more := strings.Cut(rest, "/")
+		if more != (i < len(p.segments)-1) {
+			return false
+		}
+		if !seg.param && seg.value != v {
+			return false
+		}
+		rest = next
+	}
+
+	return true
+}
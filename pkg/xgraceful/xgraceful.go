@@ -0,0 +1,85 @@
+package xgraceful
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Tracker keeps graceful shutdown state, it tracks readiness,
+// in-flight requests and background goroutines (i.e: async logging).
+type Tracker struct {
+	draining atomic.Bool
+	inflight atomic.Int64
+
+	drainOnce sync.Once
+	drained   chan struct{}
+
+	requests   sync.WaitGroup
+	background sync.WaitGroup
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{drained: make(chan struct{})}
+}
+
+// Drain flips readiness into failing, so load balancer stops routing new traffic.
+func (t *Tracker) Drain() {
+	t.draining.Store(true)
+	t.drainOnce.Do(func() { close(t.drained) })
+}
+
+// Draining is closed once draining is started, long-lived connections (i.e: sse, websocket)
+// must be closed by then, so clients reconnect into another instance.
+func (t *Tracker) Draining() <-chan struct{} {
+	return t.drained
+}
+
+func (t *Tracker) IsDraining() bool {
+	return t.draining.Load()
+}
+
+// Track marks one in-flight request, the returned function must be called once the request is done.
+func (t *Tracker) Track() (done func()) {
+	t.inflight.Add(1)
+	t.requests.Add(1)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.inflight.Add(-1)
+			t.requests.Done()
+		})
+	}
+}
+
+func (t *Tracker) InFlight() int64 {
+	return t.inflight.Load()
+}
+
+// Go runs fn in tracked background goroutine.
+func (t *Tracker) Go(fn func()) {
+	t.background.Add(1)
+	go func() {
+		defer t.background.Done()
+		fn()
+	}()
+}
+
+// Wait waits for in-flight requests first and then background goroutines,
+// it returns context error when the context is done before all of them are completed.
+func (t *Tracker) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		t.requests.Wait()
+		t.background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,100 @@
+package xgrpc
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// ServiceRegister registers grpc service implementation into every grpc server which serves its group,
+// it may implement xhuma.HandlerGroup, otherwise it belongs to the default handler group.
+type ServiceRegister interface {
+	Register(s grpc.ServiceRegistrar)
+}
+
+func AnnotateServiceAs(f any) any {
+	return fx.Annotate(
+		f,
+		fx.As(new(ServiceRegister)),
+		fx.ResultTags(`group:"global:grpc:service"`),
+	)
+}
+
+// Interceptor is the grpc counterpart of xhuma.GlobalMiddleware, it is chained by 'server.<key>.middlewares' config.
+type Interceptor interface {
+	Name() string
+	Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error)
+	Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error
+}
+
+func AnnotateInterceptorAs(f any) any {
+	return fx.Annotate(
+		f,
+		fx.As(new(Interceptor)),
+		fx.ResultTags(`group:"global:grpc:interceptor"`),
+	)
+}
+
+// ServerStream overrides the context of wrapped stream, so interceptor is able to pass values into the handler.
+type ServerStream struct {
+	grpc.ServerStream
+	Ctx context.Context
+}
+
+func (s *ServerStream) Context() context.Context {
+	return s.Ctx
+}
+
+func WrapServerStream(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &ServerStream{ServerStream: ss, Ctx: ctx}
+}
+
+// MetadataCarrier adapts incoming metadata into otel propagation.TextMapCarrier.
+type MetadataCarrier metadata.MD
+
+func (c MetadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c MetadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Header returns the first value of incoming metadata.
+func Header(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return MetadataCarrier(md).Get(key)
+}
+
+// SplitMethod splits full method name, i.e: '/grpc.health.v1.Health/Check' into 'grpc.health.v1.Health' and 'Check'.
+func SplitMethod(fullMethod string) (service, method string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
+
+// IsServerError tells whether the status code is caused by the server, it is the grpc counterpart of http 5xx.
+func IsServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package xgrpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xhealth"
+)
+
+// HealthServer serves grpc health checking protocol from xhealth registry, service name is the probe kind
+// ('liveness', 'readiness' or 'startup'), empty service name is readiness.
+type HealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	registry *xhealth.Registry
+	interval time.Duration
+}
+
+func NewHealthServer(registry *xhealth.Registry, watchInterval time.Duration) *HealthServer {
+	if watchInterval <= 0 {
+		watchInterval = 5 * time.Second
+	}
+	return &HealthServer{registry: registry, interval: watchInterval}
+}
+
+func (h *HealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	kind, ok := h.kind(req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown health service '%s'", req.GetService())
+	}
+
+	return &grpc_health_v1.HealthCheckResponse{Status: h.status(ctx, kind)}, nil
+}
+
+// Watch sends the status on every change, the status is re-checked within the watch interval.
+func (h *HealthServer) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	kind, ok := h.kind(req.GetService())
+	if !ok {
+		return stream.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN})
+	}
+
+	var (
+		ctx    = stream.Context()
+		ticker = time.NewTicker(h.interval)
+		last   = grpc_health_v1.HealthCheckResponse_UNKNOWN
+	)
+	defer ticker.Stop()
+
+	for {
+		if current := h.status(ctx, kind); current != last {
+			if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *HealthServer) kind(service string) (xhealth.Kind, bool) {
+	switch xhealth.Kind(service) {
+	case "", xhealth.KindReadiness:
+		return xhealth.KindReadiness, true
+	case xhealth.KindLiveness, xhealth.KindStartup:
+		return xhealth.Kind(service), true
+	}
+	return "", false
+}
+
+func (h *HealthServer) status(ctx context.Context, kind xhealth.Kind) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if report := h.registry.Run(ctx, kind); report.Status == xhealth.StatusDown {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
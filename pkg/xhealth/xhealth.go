@@ -0,0 +1,239 @@
+package xhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+type Kind string
+
+const (
+	KindLiveness  Kind = "liveness"
+	KindReadiness Kind = "readiness"
+	KindStartup   Kind = "startup"
+)
+
+type Status string
+
+const (
+	StatusUp       Status = "up"
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+var (
+	ErrNotStarted = errors.New("service is not started yet")
+	ErrDraining   = errors.New("service is shutting down")
+)
+
+// Check is a single dependency check which is contributed by fx module
+// through 'global:health:check' group, see AnnotateCheckAs.
+type Check struct {
+	Name  string
+	Kinds []Kind
+
+	// Critical check failure makes the probe failing, otherwise the probe is only 'degraded'.
+	Critical bool
+
+	// Timeout and CacheTTL fall back into registry default when it is zero.
+	Timeout  time.Duration
+	CacheTTL time.Duration
+
+	Fn func(ctx context.Context) error
+}
+
+type Result struct {
+	Name      string    `json:"name"`
+	Status    Status    `json:"status"`
+	Critical  bool      `json:"critical"`
+	Latency   string    `json:"latency"`
+	Error     string    `json:"error,omitempty"`
+	Cached    bool      `json:"cached"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+type Report struct {
+	Kind   Kind     `json:"kind"`
+	Status Status   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+type Options struct {
+	Timeout  time.Duration
+	CacheTTL time.Duration
+}
+
+type entry struct {
+	check Check
+
+	// mu serializes check execution, so concurrent probes share one result instead of hitting dependency.
+	mu     sync.Mutex
+	result Result
+}
+
+// Registry runs contributed checks concurrently with per check timeout and result caching.
+type Registry struct {
+	opts    Options
+	entries []*entry
+
+	started  atomic.Bool
+	draining func() bool
+}
+
+func NewRegistry(opts Options, checks ...Check) *Registry {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Second
+	}
+
+	r := &Registry{opts: opts}
+	for _, c := range checks {
+		if c.Fn == nil {
+			continue
+		}
+		if c.Timeout <= 0 {
+			c.Timeout = opts.Timeout
+		}
+		if c.CacheTTL <= 0 {
+			c.CacheTTL = opts.CacheTTL
+		}
+		r.entries = append(r.entries, &entry{check: c})
+	}
+
+	sort.Slice(r.entries, func(i, j int) bool {
+		return r.entries[i].check.Name < r.entries[j].check.Name
+	})
+
+	return r
+}
+
+// MarkStarted flips startup probe into passing, it is called once all fx start hooks are done.
+func (r *Registry) MarkStarted() {
+	r.started.Store(true)
+}
+
+func (r *Registry) IsStarted() bool {
+	return r.started.Load()
+}
+
+// SetDrainingFn sets the function which tells readiness probe that the service is shutting down.
+func (r *Registry) SetDrainingFn(fn func() bool) {
+	r.draining = fn
+}
+
+// Run runs every check of the given kind and aggregates them into single report.
+func (r *Registry) Run(ctx context.Context, kind Kind) Report {
+	var (
+		wg      sync.WaitGroup
+		entries = r.entriesOf(kind)
+		results = make([]Result, len(entries))
+	)
+
+	for i, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = e.run(ctx)
+		}()
+	}
+	wg.Wait()
+
+	switch {
+	case kind == KindStartup && !r.IsStarted():
+		results = append(results, Result{Name: "startup", Status: StatusDown, Critical: true, Error: ErrNotStarted.Error(), CheckedAt: time.Now()})
+	case kind == KindReadiness && r.draining != nil && r.draining():
+		results = append(results, Result{Name: "shutdown", Status: StatusDown, Critical: true, Error: ErrDraining.Error(), CheckedAt: time.Now()})
+	}
+
+	report := Report{Kind: kind, Status: StatusUp, Checks: results}
+	for _, res := range results {
+		if res.Status == StatusUp {
+			continue
+		}
+		if res.Critical {
+			report.Status = StatusDown
+			break
+		}
+		report.Status = StatusDegraded
+	}
+
+	return report
+}
+
+func (r *Registry) entriesOf(kind Kind) []*entry {
+	entries := make([]*entry, 0, len(r.entries))
+	for _, e := range r.entries {
+		for _, k := range e.check.Kinds {
+			if k == kind {
+				entries = append(entries, e)
+				break
+			}
+		}
+	}
+	return entries
+}
+
+func (e *entry) run(ctx context.Context) Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.result.CheckedAt.IsZero() && time.Since(e.result.CheckedAt) < e.check.CacheTTL {
+		res := e.result
+		res.Cached = true
+		return res
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.check.Timeout)
+	defer cancel()
+
+	var (
+		start = time.Now()
+		errCh = make(chan error, 1)
+	)
+
+	// the check function may ignore context, so the timeout is enforced here as well
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				errCh <- fmt.Errorf("health check panic: %v", v)
+			}
+		}()
+		errCh <- e.check.Fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	res := Result{
+		Name:      e.check.Name,
+		Status:    StatusUp,
+		Critical:  e.check.Critical,
+		Latency:   time.Since(start).String(),
+		CheckedAt: start,
+	}
+
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+
+	e.result = res
+	return res
+}
+
+func AnnotateCheckAs(f any) any {
+	return fx.Annotate(
+		f,
+		fx.ResultTags(`group:"global:health:check"`),
+	)
+}
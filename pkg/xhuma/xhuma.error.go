@@ -0,0 +1,38 @@
+package xhuma
+
+import (
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+)
+
+const (
+	// OAPIErrorCodesExtension lists the error catalog (code, status and title) in the openapi document.
+	OAPIErrorCodesExtension = "x-error-codes"
+)
+
+// RegisterErrorCatalogOAPI exports the error catalog into the openapi document as 'x-error-codes' extension
+// and 'ErrorCode' schema, it must be called after every package level error is defined (i.e: on startup).
+func RegisterErrorCatalogOAPI(oapi *huma.OpenAPI) {
+	var (
+		catalog = xerror.Catalog()
+		codes   = make([]any, len(catalog))
+	)
+
+	for i, d := range catalog {
+		codes[i] = d.Code
+	}
+
+	if oapi.Extensions == nil {
+		oapi.Extensions = make(map[string]any)
+	}
+	oapi.Extensions[OAPIErrorCodesExtension] = catalog
+
+	if oapi.Components != nil && oapi.Components.Schemas != nil {
+		oapi.Components.Schemas.Map()["ErrorCode"] = &huma.Schema{
+			Type:        huma.TypeString,
+			Description: "Stable machine-readable error code which is sent as 'err.code' of error response.",
+			Enum:        codes,
+		}
+	}
+}
@@ -1,48 +1,117 @@
 package xhuma
 
 import (
-	"net/http"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
 
 	"github.com/danielgtaylor/huma/v2"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/fx"
 
-	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xvalidate"
 )
 
-// Repalce Existing Huma New Error
+// Repalce Existing Huma New Error, catalogued error (xerror) of 'errs' takes precedence over the given status,
+// so handler can return the error as is or wrap it by huma.Error5xx and it is still responded with its own code.
 func init() {
 	huma.NewError = func(status int, msg string, errs ...error) huma.StatusError {
-		details := make([]*huma.ErrorDetail, len(errs))
+		var (
+			def     = xerror.StatusDefinition(status)
+			details = make([]*huma.ErrorDetail, 0, len(errs))
+		)
+
 		for i := range errs {
+			if errs[i] == nil {
+				continue
+			}
+			if e, ok := xerror.As(errs[i]); ok {
+				def = e.Definition()
+				if e.Detail != "" {
+					msg = e.Detail
+				}
+				continue
+			}
 			if converted, ok := errs[i].(huma.ErrorDetailer); ok {
-				details[i] = converted.ErrorDetail()
+				details = append(details, converted.ErrorDetail())
 			} else {
-				if errs[i] == nil {
-					continue
-				}
-				details[i] = &huma.ErrorDetail{Message: errs[i].Error()}
+				details = append(details, &huma.ErrorDetail{Message: errs[i].Error()})
 			}
 		}
-		return &xresp.GeneralResponseError{
-			Code: status,
-			Msg:  http.StatusText(status),
-			Err: &xresp.ErrorModel{
-				Detail: msg,
-				Errors: details,
-			},
-		}
+
+		return def.New(msg).Response(context.Background(), details...)
 	}
 
-	huma.NewErrorWithContext = func(_ huma.Context, status int, msg string, errs ...error) huma.StatusError {
+	huma.NewErrorWithContext = func(ctx huma.Context, status int, msg string, errs ...error) huma.StatusError {
+		if ctx != nil {
+			for _, err := range errs {
+				if se, ok := ValidationError(ctx.Context(), err); ok {
+					return se
+				}
+			}
+		}
 		return huma.NewError(status, msg, errs...)
 	}
 }
 
+// ValidationError converts ozzo-validation error of the handler into 422 response, the message of every field
+// is localized into the locale of the context, other error is not converted.
+func ValidationError(ctx context.Context, err error) (huma.StatusError, bool) {
+	var verrs validation.Errors
+	if !errors.As(err, &verrs) {
+		return nil, false
+	}
+
+	errs, ok := xvalidate.IsErrors(xvalidate.WrapperValidationLocalized(ctx, verrs))
+	if !ok {
+		return nil, false
+	}
+
+	fields := make([]string, 0, len(errs))
+	for field := range errs {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	details := make([]*huma.ErrorDetail, 0, len(fields))
+	for _, field := range fields {
+		msg, ok := errs[field].(string)
+		if !ok {
+			// nested struct errors are kept as json object
+			b, _ := json.Marshal(errs[field])
+			msg = string(b)
+		}
+		details = append(details, &huma.ErrorDetail{Location: field, Message: msg})
+	}
+
+	return xerror.ErrUnprocessableEntity.New("validation failed").Response(ctx, details...), true
+}
+
 type HandlerRegister interface {
 	Register(api huma.API)
 }
 
+const (
+	DefaultHandlerGroup = "public"
+)
+
+// HandlerGroup is an optional interface for HandlerRegister (or any other route register), it tells which server
+// will serve the handler based on 'server.<key>.handler.groups' config.
+// Handler without this interface belongs to DefaultHandlerGroup.
+type HandlerGroup interface {
+	Group() string
+}
+
+func HandlerGroupOf(h any) string {
+	if g, ok := h.(HandlerGroup); ok && g.Group() != "" {
+		return g.Group()
+	}
+	return DefaultHandlerGroup
+}
+
 func AnnotateHandlerAs(f any) any {
 	return fx.Annotate(
 		f,
@@ -0,0 +1,68 @@
+package xhuma
+
+import (
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+const (
+	// MetadataTimeout declares operation timeout in 'huma.Operation.Metadata',
+	// the value is time.Duration, i.e: Metadata: map[string]any{xhuma.MetadataTimeout: 5 * time.Second}
+	MetadataTimeout = "timeout"
+
+	// MetadataLogBody declares whether request and response body of the operation is logged by incoming log,
+	// the value is bool, i.e: Metadata: map[string]any{xhuma.MetadataLogBody: false}
+	MetadataLogBody = "log.body"
+
+	// MetadataAudit declares whether mutating operation is recorded in audit trail, the value is bool,
+	// i.e: Metadata: map[string]any{xhuma.MetadataAudit: false}
+	MetadataAudit = "audit"
+
+	// MetadataAuditResource declares resource type of audit entry, the value is string, default is the first tag,
+	// i.e: Metadata: map[string]any{xhuma.MetadataAuditResource: "user"}
+	MetadataAuditResource = "audit.resource"
+)
+
+func OperationTimeout(op *huma.Operation) (time.Duration, bool) {
+	if op == nil || op.Metadata == nil {
+		return 0, false
+	}
+
+	d, ok := op.Metadata[MetadataTimeout].(time.Duration)
+	return d, ok && d > 0
+}
+
+func OperationLogBody(op *huma.Operation) (bool, bool) {
+	if op == nil || op.Metadata == nil {
+		return false, false
+	}
+
+	v, ok := op.Metadata[MetadataLogBody].(bool)
+	return v, ok
+}
+
+func OperationAudit(op *huma.Operation) (bool, bool) {
+	if op == nil || op.Metadata == nil {
+		return false, false
+	}
+
+	v, ok := op.Metadata[MetadataAudit].(bool)
+	return v, ok
+}
+
+func OperationAuditResource(op *huma.Operation) string {
+	if op == nil {
+		return ""
+	}
+
+	if v, ok := op.Metadata[MetadataAuditResource].(string); ok && v != "" {
+		return v
+	}
+
+	if len(op.Tags) > 0 {
+		return op.Tags[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,54 @@
+package xhuma
+
+import (
+	"github.com/danielgtaylor/huma/v2"
+	"go.uber.org/fx"
+)
+
+const (
+	// MetadataMiddlewares declares named operation middlewares in 'huma.Operation.Metadata', the value is []string,
+	// i.e: Metadata: map[string]any{xhuma.MetadataMiddlewares: []string{"auth"}}
+	// they are applied in the registry order, not in the declared order.
+	MetadataMiddlewares = "middlewares"
+)
+
+// OperationMiddleware is named huma middleware which is referenced by operation through MetadataMiddlewares.
+type OperationMiddleware interface {
+	Name() string
+	Serve(c huma.Context, next func(huma.Context))
+}
+
+func AnnotateOperationMiddlewareAs(f any) any {
+	return fx.Annotate(
+		f,
+		fx.As(new(OperationMiddleware)),
+		fx.ResultTags(`group:"global:http:operation:middleware"`),
+	)
+}
+
+// NewOperationMiddleware names plain huma middleware, i.e: method value of shared middleware.
+func NewOperationMiddleware(name string, serve func(c huma.Context, next func(huma.Context))) *NamedOperationMiddleware {
+	return &NamedOperationMiddleware{name, serve}
+}
+
+type NamedOperationMiddleware struct {
+	name  string
+	serve func(c huma.Context, next func(huma.Context))
+}
+
+func (m *NamedOperationMiddleware) Name() string {
+	return m.name
+}
+
+func (m *NamedOperationMiddleware) Serve(c huma.Context, next func(huma.Context)) {
+	m.serve(c, next)
+}
+
+func OperationMiddlewares(op *huma.Operation) []string {
+	if op == nil || op.Metadata == nil {
+		return nil
+	}
+
+	names, _ := op.Metadata[MetadataMiddlewares].([]string)
+	return names
+}
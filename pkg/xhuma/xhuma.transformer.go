@@ -3,7 +3,9 @@ package xhuma
 import (
 	"reflect"
 
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xerror"
 	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xresp"
 	"github.com/danielgtaylor/huma/v2"
 )
 
@@ -42,3 +44,11 @@ func TransformerTraceIdSetter(ctx huma.Context, status string, v any) (any, erro
 	// Other types — do nothing
 	return v, nil
 }
+
+// TransformerLocalize localizes error response into the negotiated locale of the request (xi18n.WithLocale).
+func TransformerLocalize(ctx huma.Context, status string, v any) (any, error) {
+	if r, ok := v.(*xresp.GeneralResponseError); ok {
+		xerror.Localize(ctx.Context(), r)
+	}
+	return v, nil
+}
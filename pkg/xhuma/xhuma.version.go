@@ -0,0 +1,103 @@
+package xhuma
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+const (
+	// MetadataVersion is set by VersionModifier into 'huma.Operation.Metadata', the value is version key, i.e: "v1".
+	MetadataVersion = "version"
+
+	// MetadataDeprecation declares operation deprecation in 'huma.Operation.Metadata',
+	// the value is xhuma.Deprecation, i.e: Metadata: map[string]any{xhuma.MetadataDeprecation: xhuma.Deprecation{Sunset: sunset}}
+	MetadataDeprecation = "deprecation"
+)
+
+// HandlerVersion is an optional interface for HandlerRegister, the handler is registered into the api
+// of 'api.versions.<version>' config, so its operation path is prefixed by the version prefix
+// and it is documented in the version openapi document.
+// Handler without this interface is registered as is into the server api.
+type HandlerVersion interface {
+	Version() string
+}
+
+func HandlerVersionOf(h any) string {
+	if v, ok := h.(HandlerVersion); ok {
+		return v.Version()
+	}
+	return ""
+}
+
+// Deprecation is emitted as 'Deprecation', 'Sunset' and 'Link' response headers.
+type Deprecation struct {
+	// Date since the operation is deprecated, zero value means it is deprecated without specific date.
+	Date time.Time
+
+	// Sunset is the date when the operation is going to be removed, it is optional.
+	Sunset time.Time
+
+	// Link is migration guide url, it is optional.
+	Link string
+}
+
+// Header returns response headers of the deprecation, see RFC 9745 and RFC 8594.
+func (d Deprecation) Header() map[string]string {
+	h := map[string]string{"Deprecation": "true"}
+	if !d.Date.IsZero() {
+		h["Deprecation"] = "@" + strconv.FormatInt(d.Date.Unix(), 10)
+	}
+	if !d.Sunset.IsZero() {
+		h["Sunset"] = d.Sunset.UTC().Format(http.TimeFormat)
+	}
+	if d.Link != "" {
+		h["Link"] = "<" + d.Link + ">; rel=\"deprecation\"; type=\"text/html\""
+	}
+	return h
+}
+
+func OperationVersion(op *huma.Operation) string {
+	if op == nil || op.Metadata == nil {
+		return ""
+	}
+
+	v, _ := op.Metadata[MetadataVersion].(string)
+	return v
+}
+
+func OperationDeprecation(op *huma.Operation) (Deprecation, bool) {
+	if op == nil || op.Metadata == nil {
+		return Deprecation{}, false
+	}
+
+	d, ok := op.Metadata[MetadataDeprecation].(Deprecation)
+	return d, ok
+}
+
+// VersionModifier is huma group modifier, it tags operation with the version and marks it as deprecated
+// when either the operation or the whole version (non-nil 'deprecation') is deprecated,
+// the operation deprecation takes precedence over the version deprecation.
+func VersionModifier(version string, deprecation *Deprecation) func(op *huma.Operation, next func(*huma.Operation)) {
+	return func(op *huma.Operation, next func(*huma.Operation)) {
+		if op.Metadata == nil {
+			op.Metadata = make(map[string]any)
+		}
+
+		if version != "" {
+			op.Metadata[MetadataVersion] = version
+		}
+
+		if _, ok := op.Metadata[MetadataDeprecation].(Deprecation); !ok && deprecation != nil {
+			op.Metadata[MetadataDeprecation] = *deprecation
+		}
+
+		if _, ok := op.Metadata[MetadataDeprecation].(Deprecation); ok {
+			op.Deprecated = true
+		}
+
+		next(op)
+	}
+}
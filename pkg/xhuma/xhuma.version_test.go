@@ -0,0 +1,119 @@
+package xhuma
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func TestDeprecationHeader(t *testing.T) {
+	var (
+		date   = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+		sunset = time.Date(2025, 7, 1, 12, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+	)
+
+	tests := []struct {
+		name string
+		d    Deprecation
+		want map[string]string
+	}{
+		{
+			name: "without date",
+			d:    Deprecation{},
+			want: map[string]string{"Deprecation": "true"},
+		},
+		{
+			name: "with date",
+			d:    Deprecation{Date: date},
+			want: map[string]string{"Deprecation": "@1735776000"},
+		},
+		{
+			name: "with sunset and link",
+			d:    Deprecation{Date: date, Sunset: sunset, Link: "https://example.com/migrate"},
+			want: map[string]string{
+				"Deprecation": "@1735776000",
+				"Sunset":      "Tue, 01 Jul 2025 05:00:00 GMT",
+				"Link":        `<https://example.com/migrate>; rel="deprecation"; type="text/html"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Header(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Header() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionModifier(t *testing.T) {
+	var (
+		version = &Deprecation{Link: "https://example.com/v1"}
+		own     = Deprecation{Link: "https://example.com/op"}
+	)
+
+	tests := []struct {
+		name        string
+		version     string
+		deprecation *Deprecation
+		metadata    map[string]any
+		want        Deprecation
+		deprecated  bool
+	}{
+		{
+			name:    "active version",
+			version: "v2",
+		},
+		{
+			name:        "deprecated version",
+			version:     "v1",
+			deprecation: version,
+			want:        *version,
+			deprecated:  true,
+		},
+		{
+			name:        "operation deprecation takes precedence",
+			version:     "v1",
+			deprecation: version,
+			metadata:    map[string]any{MetadataDeprecation: own},
+			want:        own,
+			deprecated:  true,
+		},
+		{
+			name:       "deprecated operation of active version",
+			version:    "v2",
+			metadata:   map[string]any{MetadataDeprecation: own},
+			want:       own,
+			deprecated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				op   = &huma.Operation{Metadata: tt.metadata}
+				next bool
+			)
+
+			VersionModifier(tt.version, tt.deprecation)(op, func(*huma.Operation) { next = true })
+
+			if !next {
+				t.Fatal("VersionModifier() does not call next")
+			}
+			if got := OperationVersion(op); got != tt.version {
+				t.Errorf("OperationVersion() = %q, want %q", got, tt.version)
+			}
+			if op.Deprecated != tt.deprecated {
+				t.Errorf("Deprecated = %v, want %v", op.Deprecated, tt.deprecated)
+			}
+
+			got, ok := OperationDeprecation(op)
+			if ok != tt.deprecated || got != tt.want {
+				t.Errorf("OperationDeprecation() = %v, %v, want %v, %v", got, ok, tt.want, tt.deprecated)
+			}
+		})
+	}
+}
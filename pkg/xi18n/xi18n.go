@@ -0,0 +1,247 @@
+package xi18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	// SourceLocale is the locale which messages are written in the code, i.e: http.StatusText and huma validation
+	// messages, its catalog is used as patterns to translate those messages into the other locales.
+	SourceLocale = "en"
+)
+
+var (
+	std atomic.Pointer[Bundle]
+
+	// empty is used by package level helpers until the default bundle is set, so the key is returned as message.
+	empty = &Bundle{}
+
+	// PatternPrefixes are key prefixes of SourceLocale messages which are sent by the code as is, i.e: huma and ozzo-validation
+	// messages and catalogued error details, only these keys are matched by Translate, so a message of other key (i.e: email)
+	// is never translated by accident.
+	PatternPrefixes = []string{"huma.", "ozzo.", "detail."}
+
+	// verbs are fmt verbs which are used as placeholders of the source message.
+	verbs = regexp.MustCompile(`%[vsdq]`)
+)
+
+// SetDefault sets the bundle which is used by package level helpers, i.e: error response localization.
+func SetDefault(b *Bundle) {
+	std.Store(b)
+}
+
+// Default returns the default bundle, it is nil until SetDefault is called.
+func Default() *Bundle {
+	return std.Load()
+}
+
+type ctxKey struct{}
+
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, locale)
+}
+
+// LocaleFrom returns the negotiated locale of the request, otherwise empty string.
+func LocaleFrom(ctx context.Context) string {
+	s, _ := ctx.Value(ctxKey{}).(string)
+	return s
+}
+
+// T translates the key into the locale of the context using the default bundle.
+func T(ctx context.Context, key string, args ...any) string {
+	b := Default()
+	if b == nil {
+		b = empty
+	}
+	return b.T(LocaleFrom(ctx), key, args...)
+}
+
+// Bundle is message catalogs of every locale, it is loaded from '<dir>/<locale>.json' files,
+// each file is a flat json object of message key and message.
+type Bundle struct {
+	fallback string
+	locales  []string
+	matcher  language.Matcher
+	messages map[string]map[string]string
+	patterns []pattern
+}
+
+// pattern matches message which is written in SourceLocale, so it is translated by its key.
+type pattern struct {
+	key     string
+	literal int
+	re      *regexp.Regexp
+}
+
+// Load reads every '*.json' catalog of the directory, the fallback locale catalog must exist.
+func Load(dir string, fallback string) (*Bundle, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read i18n catalog '%s': %w", file, err)
+		}
+
+		var m map[string]string
+		if err := json.Unmarshal(b, &m); err != nil {
+			return nil, fmt.Errorf("failed to parse i18n catalog '%s': %w", file, err)
+		}
+
+		messages[strings.TrimSuffix(filepath.Base(file), ".json")] = m
+	}
+
+	return New(messages, fallback)
+}
+
+// New creates bundle from catalogs which are keyed by locale, i.e: "en" and "id".
+func New(messages map[string]map[string]string, fallback string) (*Bundle, error) {
+	if fallback == "" {
+		fallback = SourceLocale
+	}
+
+	if _, ok := messages[fallback]; !ok {
+		return nil, fmt.Errorf("i18n catalog of fallback locale '%s' is not found", fallback)
+	}
+
+	b := &Bundle{
+		fallback: fallback,
+		messages: messages,
+	}
+
+	// the first supported tag is used by the matcher when nothing is matched
+	b.locales = append(b.locales, fallback)
+	for locale := range messages {
+		if locale != fallback {
+			b.locales = append(b.locales, locale)
+		}
+	}
+	sort.Strings(b.locales[1:])
+
+	tags := make([]language.Tag, len(b.locales))
+	for i, locale := range b.locales {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return nil, fmt.Errorf("invalid i18n locale '%s': %w", locale, err)
+		}
+		tags[i] = tag
+	}
+	b.matcher = language.NewMatcher(tags)
+
+	for key, msg := range messages[SourceLocale] {
+		if !slices.ContainsFunc(PatternPrefixes, func(prefix string) bool { return strings.HasPrefix(key, prefix) }) {
+			continue
+		}
+
+		expr := "^" + verbs.ReplaceAllLiteralString(regexp.QuoteMeta(msg), "(.+?)") + "$"
+		b.patterns = append(b.patterns, pattern{
+			key:     key,
+			literal: len(verbs.ReplaceAllLiteralString(msg, "")),
+			re:      regexp.MustCompile(expr),
+		})
+	}
+
+	// the most specific pattern wins, i.e: "expected string to be RFC 3339 date" over "expected string to be %s"
+	sort.Slice(b.patterns, func(i, j int) bool {
+		if b.patterns[i].literal != b.patterns[j].literal {
+			return b.patterns[i].literal > b.patterns[j].literal
+		}
+		return b.patterns[i].key < b.patterns[j].key
+	})
+
+	return b, nil
+}
+
+func (b *Bundle) Fallback() string {
+	return b.fallback
+}
+
+func (b *Bundle) Locales() []string {
+	return b.locales
+}
+
+// Match negotiates the locale from 'Accept-Language' header value, the fallback locale is returned when nothing is matched.
+func (b *Bundle) Match(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return b.fallback
+	}
+
+	_, idx, conf := b.matcher.Match(tags...)
+	if conf == language.No {
+		return b.fallback
+	}
+
+	return b.locales[idx]
+}
+
+// Message returns the message of the key, it looks up the locale, then the fallback and lastly SourceLocale catalog.
+func (b *Bundle) Message(locale, key string) (string, bool) {
+	for _, l := range []string{locale, b.fallback, SourceLocale} {
+		if msg, ok := b.messages[l][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// T returns the formatted message of the key (fmt verbs), otherwise the key itself.
+func (b *Bundle) T(locale, key string, args ...any) string {
+	msg, ok := b.Message(locale, key)
+	if !ok {
+		msg = key
+	}
+	return format(msg, args...)
+}
+
+// Translate translates message which is written in SourceLocale, i.e: "expected number >= 1" is translated
+// by 'huma.expected.minimum.number' key. The message is returned as is when no pattern is matched.
+func (b *Bundle) Translate(locale, msg string) string {
+	if msg == "" || locale == "" {
+		return msg
+	}
+
+	for _, p := range b.patterns {
+		m := p.re.FindStringSubmatch(msg)
+		if m == nil {
+			continue
+		}
+
+		localized, ok := b.Message(locale, p.key)
+		if !ok {
+			return msg
+		}
+
+		args := make([]any, len(m)-1)
+		for i, v := range m[1:] {
+			args[i] = v
+		}
+
+		// the arguments are extracted as string, so every verb of the localized message is formatted as string
+		return format(verbs.ReplaceAllLiteralString(localized, "%s"), args...)
+	}
+
+	return msg
+}
+
+func format(msg string, args ...any) string {
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
@@ -0,0 +1,20 @@
+package xi18n
+
+// FuncMap feeds the catalog into html or text template (i.e: email template), the locale is fixed for the rendering.
+//
+//	<p>{{ t "email.greeting" .Name }}</p>
+//	<html lang="{{ locale }}">
+func (b *Bundle) FuncMap(locale string) map[string]any {
+	if locale == "" {
+		locale = b.fallback
+	}
+
+	return map[string]any{
+		"t": func(key string, args ...any) string {
+			return b.T(locale, key, args...)
+		},
+		"locale": func() string {
+			return locale
+		},
+	}
+}
@@ -0,0 +1,212 @@
+package xi18n
+
+import (
+	"context"
+	"testing"
+)
+
+func newTestBundle(t *testing.T) *Bundle {
+	t.Helper()
+
+	b, err := New(map[string]map[string]string{
+		"en": {
+			"huma.expected.minimum.number": "expected number >= %v",
+			"huma.expected.string":         "expected string to be %s",
+			"huma.expected.rfc3339":        "expected string to be RFC 3339 date",
+			"detail.too_many_requests":     "too many requests, retry after %s seconds",
+			"detail.filter_operation":      "filter field '%s' does not support operation '%s'",
+			"detail.untranslated":          "only in source locale",
+			"email.welcome":                "welcome",
+			"greeting":                     "hello %s",
+		},
+		"id": {
+			"huma.expected.minimum.number": "angka harus >= %v",
+			"huma.expected.string":         "string harus berupa %s",
+			"huma.expected.rfc3339":        "string harus berupa tanggal RFC 3339",
+			"detail.too_many_requests":     "terlalu banyak permintaan, coba lagi setelah %s detik",
+			"detail.filter_operation":      "field filter '%s' tidak mendukung operasi '%s'",
+			"email.welcome":                "selamat datang",
+			"greeting":                     "halo %s",
+		},
+	}, "en")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return b
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages map[string]map[string]string
+		fallback string
+		wantErr  bool
+	}{
+		{name: "default fallback", messages: map[string]map[string]string{"en": {}}},
+		{name: "fallback catalog is missing", messages: map[string]map[string]string{"en": {}}, fallback: "id", wantErr: true},
+		{name: "invalid locale", messages: map[string]map[string]string{"en": {}, "not a locale": {}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.messages, tt.fallback)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBundleTranslate(t *testing.T) {
+	b := newTestBundle(t)
+
+	tests := []struct {
+		name   string
+		locale string
+		msg    string
+		want   string
+	}{
+		{
+			name:   "single placeholder",
+			locale: "id",
+			msg:    "expected number >= 1",
+			want:   "angka harus >= 1",
+		},
+		{
+			name:   "most specific pattern wins",
+			locale: "id",
+			msg:    "expected string to be RFC 3339 date",
+			want:   "string harus berupa tanggal RFC 3339",
+		},
+		{
+			name:   "less specific pattern",
+			locale: "id",
+			msg:    "expected string to be uuid",
+			want:   "string harus berupa uuid",
+		},
+		{
+			name:   "multiple placeholders",
+			locale: "id",
+			msg:    "filter field 'name' does not support operation 'gt'",
+			want:   "field filter 'name' tidak mendukung operasi 'gt'",
+		},
+		{
+			name:   "detail message",
+			locale: "id",
+			msg:    "too many requests, retry after 3 seconds",
+			want:   "terlalu banyak permintaan, coba lagi setelah 3 detik",
+		},
+		{
+			name:   "key without pattern prefix is never matched",
+			locale: "id",
+			msg:    "welcome",
+			want:   "welcome",
+		},
+		{
+			name:   "unknown message is kept",
+			locale: "id",
+			msg:    "something else happened",
+			want:   "something else happened",
+		},
+		{
+			name:   "message is missing in the locale, fallback is used",
+			locale: "id",
+			msg:    "only in source locale",
+			want:   "only in source locale",
+		},
+		{
+			name:   "source locale",
+			locale: "en",
+			msg:    "expected number >= 1",
+			want:   "expected number >= 1",
+		},
+		{
+			name:   "empty locale",
+			locale: "",
+			msg:    "expected number >= 1",
+			want:   "expected number >= 1",
+		},
+		{
+			name:   "placeholder must not be empty",
+			locale: "id",
+			msg:    "expected number >= ",
+			want:   "expected number >= ",
+		},
+		{
+			name:   "partial match is not translated",
+			locale: "id",
+			msg:    "error: expected number >= 1",
+			want:   "error: expected number >= 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Translate(tt.locale, tt.msg); got != tt.want {
+				t.Fatalf("Translate(%q, %q) = %q, want %q", tt.locale, tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBundleT(t *testing.T) {
+	b := newTestBundle(t)
+
+	tests := []struct {
+		name   string
+		locale string
+		key    string
+		args   []any
+		want   string
+	}{
+		{name: "locale message", locale: "id", key: "greeting", args: []any{"budi"}, want: "halo budi"},
+		{name: "unknown locale falls back", locale: "fr", key: "greeting", args: []any{"anne"}, want: "hello anne"},
+		{name: "missing message falls back", locale: "id", key: "detail.untranslated", want: "only in source locale"},
+		{name: "unknown key is returned as is", locale: "id", key: "unknown.key", want: "unknown.key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.T(tt.locale, tt.key, tt.args...); got != tt.want {
+				t.Fatalf("T(%q, %q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBundleMatch(t *testing.T) {
+	b := newTestBundle(t)
+
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{accept: "id-ID,id;q=0.9,en;q=0.8", want: "id"},
+		{accept: "en-US", want: "en"},
+		{accept: "fr-FR", want: "en"},
+		{accept: "", want: "en"},
+		{accept: "!!invalid", want: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			if got := b.Match(tt.accept); got != tt.want {
+				t.Fatalf("Match(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageT(t *testing.T) {
+	t.Cleanup(func() { std.Store(nil) })
+
+	ctx := WithLocale(context.Background(), "id")
+	if got := T(ctx, "greeting"); got != "greeting" {
+		t.Fatalf("T() without default bundle = %q, want the key", got)
+	}
+
+	SetDefault(newTestBundle(t))
+	if got := T(ctx, "greeting", "budi"); got != "halo budi" {
+		t.Fatalf("T() = %q, want %q", got, "halo budi")
+	}
+}
@@ -0,0 +1,104 @@
+package xlimiter
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+type AdaptiveConfig struct {
+	InitialLimit     int
+	MinLimit         int
+	MaxLimit         int
+	LatencyThreshold time.Duration
+	BackoffRatio     float64
+}
+
+// AdaptiveLimiter is AIMD (additive increase, multiplicative decrease) concurrency limiter.
+//
+// The limit grows by 1/limit every time a request is completed under the latency threshold
+// while the limiter is at least half utilized, so it grows by about one per round trip of the whole limit,
+// and is multiplied by backoff ratio when request is completed above latency threshold or failed by overload.
+type AdaptiveLimiter struct {
+	mu       sync.Mutex
+	cfg      AdaptiveConfig
+	limit    float64
+	inflight int
+	latency  time.Duration
+}
+
+func NewAdaptiveLimiter(cfg AdaptiveConfig) *AdaptiveLimiter {
+	if cfg.MinLimit <= 0 {
+		cfg.MinLimit = 1
+	}
+	if cfg.MaxLimit < cfg.MinLimit {
+		cfg.MaxLimit = math.MaxInt32
+	}
+	if cfg.InitialLimit < cfg.MinLimit {
+		cfg.InitialLimit = cfg.MinLimit
+	}
+	if cfg.BackoffRatio <= 0 || cfg.BackoffRatio >= 1 {
+		cfg.BackoffRatio = 0.9
+	}
+
+	return &AdaptiveLimiter{cfg: cfg, limit: float64(cfg.InitialLimit)}
+}
+
+// Acquire reserves one slot, the returned release function must be called
+// once the request is completed with its latency and whether it is overloaded.
+func (l *AdaptiveLimiter) Acquire() (release func(latency time.Duration, overload bool), ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inflight >= int(l.limit) {
+		return nil, false
+	}
+
+	l.inflight++
+
+	var once sync.Once
+	return func(latency time.Duration, overload bool) {
+		once.Do(func() { l.release(latency, overload) })
+	}, true
+}
+
+func (l *AdaptiveLimiter) release(latency time.Duration, overload bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	inflight := l.inflight
+	l.inflight--
+
+	// exponential moving average, for observability only
+	if l.latency == 0 {
+		l.latency = latency
+	} else {
+		l.latency = time.Duration(0.8*float64(l.latency) + 0.2*float64(latency))
+	}
+
+	if overload || (l.cfg.LatencyThreshold > 0 && latency > l.cfg.LatencyThreshold) {
+		l.limit = math.Max(float64(l.cfg.MinLimit), l.limit*l.cfg.BackoffRatio)
+		return
+	}
+
+	if inflight*2 >= int(l.limit) {
+		l.limit = math.Min(float64(l.cfg.MaxLimit), l.limit+1/l.limit)
+	}
+}
+
+type AdaptiveSnapshot struct {
+	Limit    int
+	Inflight int
+	Latency  time.Duration
+}
+
+func (l *AdaptiveLimiter) Snapshot() AdaptiveSnapshot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return AdaptiveSnapshot{
+		Limit:    int(l.limit),
+		Inflight: l.inflight,
+		Latency:  l.latency,
+	}
+}
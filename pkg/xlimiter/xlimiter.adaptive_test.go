@@ -0,0 +1,174 @@
+package xlimiter
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewAdaptiveLimiterDefaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   AdaptiveConfig
+		limit int
+		min   int
+		max   int
+		ratio float64
+	}{
+		{
+			name:  "zero config",
+			cfg:   AdaptiveConfig{},
+			limit: 1,
+			min:   1,
+			max:   math.MaxInt32,
+			ratio: 0.9,
+		},
+		{
+			name:  "initial limit below min limit",
+			cfg:   AdaptiveConfig{InitialLimit: 2, MinLimit: 5, MaxLimit: 10, BackoffRatio: 0.5},
+			limit: 5,
+			min:   5,
+			max:   10,
+			ratio: 0.5,
+		},
+		{
+			name:  "max limit below min limit and invalid backoff ratio",
+			cfg:   AdaptiveConfig{InitialLimit: 20, MinLimit: 10, MaxLimit: 5, BackoffRatio: 1.5},
+			limit: 20,
+			min:   10,
+			max:   math.MaxInt32,
+			ratio: 0.9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewAdaptiveLimiter(tt.cfg)
+
+			if got := l.Snapshot().Limit; got != tt.limit {
+				t.Errorf("Limit = %d, want %d", got, tt.limit)
+			}
+			if l.cfg.MinLimit != tt.min {
+				t.Errorf("MinLimit = %d, want %d", l.cfg.MinLimit, tt.min)
+			}
+			if l.cfg.MaxLimit != tt.max {
+				t.Errorf("MaxLimit = %d, want %d", l.cfg.MaxLimit, tt.max)
+			}
+			if l.cfg.BackoffRatio != tt.ratio {
+				t.Errorf("BackoffRatio = %v, want %v", l.cfg.BackoffRatio, tt.ratio)
+			}
+		})
+	}
+}
+
+func TestAdaptiveLimiterAcquire(t *testing.T) {
+	l := NewAdaptiveLimiter(AdaptiveConfig{InitialLimit: 2, MinLimit: 1, MaxLimit: 2})
+
+	first, ok := l.Acquire()
+	if !ok {
+		t.Fatal("first Acquire() is rejected, want accepted")
+	}
+	if _, ok := l.Acquire(); !ok {
+		t.Fatal("second Acquire() is rejected, want accepted")
+	}
+	if release, ok := l.Acquire(); ok || release != nil {
+		t.Fatal("third Acquire() is accepted, want rejected once the limit is reached")
+	}
+
+	// release is only applied once
+	first(time.Millisecond, false)
+	first(time.Millisecond, false)
+	if got := l.Snapshot().Inflight; got != 1 {
+		t.Fatalf("Inflight = %d, want 1", got)
+	}
+
+	if _, ok := l.Acquire(); !ok {
+		t.Fatal("Acquire() after release is rejected, want accepted")
+	}
+}
+
+func TestAdaptiveLimiterRelease(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      AdaptiveConfig
+		inflight int
+		latency  time.Duration
+		overload bool
+		limit    float64
+	}{
+		{
+			name:     "fast request grows the limit by 1/limit",
+			cfg:      AdaptiveConfig{InitialLimit: 4, MaxLimit: 100, LatencyThreshold: time.Second},
+			inflight: 2,
+			latency:  time.Millisecond,
+			limit:    4.25,
+		},
+		{
+			name:     "fast request does not grow under utilized limit",
+			cfg:      AdaptiveConfig{InitialLimit: 4, MaxLimit: 100, LatencyThreshold: time.Second},
+			inflight: 1,
+			latency:  time.Millisecond,
+			limit:    4,
+		},
+		{
+			name:     "growth is capped by max limit",
+			cfg:      AdaptiveConfig{InitialLimit: 4, MaxLimit: 4, LatencyThreshold: time.Second},
+			inflight: 4,
+			latency:  time.Millisecond,
+			limit:    4,
+		},
+		{
+			name:     "slow request backs off the limit",
+			cfg:      AdaptiveConfig{InitialLimit: 10, MaxLimit: 100, LatencyThreshold: time.Second, BackoffRatio: 0.5},
+			inflight: 1,
+			latency:  2 * time.Second,
+			limit:    5,
+		},
+		{
+			name:     "overload backs off the limit",
+			cfg:      AdaptiveConfig{InitialLimit: 10, MaxLimit: 100, BackoffRatio: 0.5},
+			inflight: 1,
+			latency:  time.Millisecond,
+			overload: true,
+			limit:    5,
+		},
+		{
+			name:     "backoff is capped by min limit",
+			cfg:      AdaptiveConfig{InitialLimit: 10, MinLimit: 8, MaxLimit: 100, BackoffRatio: 0.5},
+			inflight: 1,
+			overload: true,
+			limit:    8,
+		},
+		{
+			name:     "zero latency threshold never backs off by latency",
+			cfg:      AdaptiveConfig{InitialLimit: 2, MaxLimit: 100},
+			inflight: 1,
+			latency:  time.Hour,
+			limit:    2.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewAdaptiveLimiter(tt.cfg)
+
+			releases := make([]func(time.Duration, bool), 0, tt.inflight)
+			for range tt.inflight {
+				release, ok := l.Acquire()
+				if !ok {
+					t.Fatal("Acquire() is rejected, want accepted")
+				}
+				releases = append(releases, release)
+			}
+
+			releases[0](tt.latency, tt.overload)
+
+			if l.limit != tt.limit {
+				t.Errorf("limit = %v, want %v", l.limit, tt.limit)
+			}
+			if got := l.Snapshot().Inflight; got != tt.inflight-1 {
+				t.Errorf("Inflight = %d, want %d", got, tt.inflight-1)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package xlimiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/xid"
+)
+
+const (
+	AlgorithmSlidingWindow = "sliding.window"
+	AlgorithmTokenBucket   = "token.bucket"
+)
+
+var (
+	ErrUnknownAlgorithm = errors.New("unknown rate limit algorithm")
+)
+
+// Rule defines how many request (Limit) is allowed within a Window.
+// For token bucket, Limit is the bucket capacity and it is refilled
+// with Limit tokens evenly across the Window.
+type Rule struct {
+	Algorithm string
+	Limit     int
+	Window    time.Duration
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration
+}
+
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{client}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	if rule.Limit <= 0 || rule.Window <= 0 {
+		return Result{Allowed: true, Limit: rule.Limit}, nil
+	}
+
+	var (
+		window = rule.Window.Milliseconds()
+		res    []int64
+		err    error
+	)
+
+	// the scripts read redis clock, so instances with clock skew agree on the window
+	switch rule.Algorithm {
+	case "", AlgorithmSlidingWindow:
+		res, err = slidingWindowScript.Run(ctx, l.client, []string{key}, window, rule.Limit, xid.New().String()).Int64Slice()
+	case AlgorithmTokenBucket:
+		res, err = tokenBucketScript.Run(ctx, l.client, []string{key}, window, rule.Limit).Int64Slice()
+	default:
+		return Result{}, fmt.Errorf("%w: '%s'", ErrUnknownAlgorithm, rule.Algorithm)
+	}
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	if len(res) != 3 {
+		return Result{}, fmt.Errorf("unexpected rate limit script result: %v", res)
+	}
+
+	return Result{
+		Allowed:   res[0] == 1,
+		Limit:     rule.Limit,
+		Remaining: int(res[1]),
+		Reset:     time.Duration(res[2]) * time.Millisecond,
+	}, nil
+}
+
+// slidingWindowScript keeps every request timestamp in sorted set,
+// returns: {allowed, remaining, reset_in_ms}
+var slidingWindowScript = redis.NewScript(`
+local key    = KEYS[1]
+local window = tonumber(ARGV[1])
+local limit  = tonumber(ARGV[2])
+local member = ARGV[3]
+local time   = redis.call('TIME')
+local now    = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+redis.call('ZREMRANGEBYSCORE', key, 0, now - window)
+
+local allowed   = 0
+local remaining = 0
+local count     = redis.call('ZCARD', key)
+if count < limit then
+	redis.call('ZADD', key, now, member)
+	redis.call('PEXPIRE', key, window)
+	allowed   = 1
+	remaining = limit - count - 1
+end
+
+-- the window is reset when the oldest request is out of the window
+local reset  = window
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, remaining, reset}
+`)
+
+// tokenBucketScript keeps tokens and last refill timestamp in hash,
+// returns: {allowed, remaining, reset_in_ms}
+var tokenBucketScript = redis.NewScript(`
+local key      = KEYS[1]
+local window   = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local rate     = capacity / window
+local time     = redis.call('TIME')
+local now      = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local data   = redis.call('HMGET', key, 'tokens', 'ts')
+local tokens = tonumber(data[1]) or capacity
+local ts     = tonumber(data[2]) or now
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens  = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', key, 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', key, window)
+
+local reset = 0
+if allowed == 0 then
+	reset = math.ceil((1 - tokens) / rate)
+else
+	reset = math.ceil((capacity - tokens) / rate)
+end
+
+return {allowed, math.floor(tokens), reset}
+`)
@@ -17,6 +17,7 @@ func (s CtxKey) String() string {
 
 const (
 	XLOG_REQ_TRACE_ID_CTX_KEY  CtxKey = "XLOG_REQ_TRACE_ID_CTX_KEY"
+	XLOG_REQ_TENANT_ID_CTX_KEY CtxKey = "XLOG_REQ_TENANT_ID_CTX_KEY"
 	XLOG_HIDE_RES_FLAG_CTX_KEY CtxKey = "XLOG_HIDE_RES_FLAG_CTX_KEY"
 )
 
@@ -40,6 +41,11 @@ func GetReqTraceID(ctx context.Context) string {
 	return s
 }
 
+func GetReqTenantID(ctx context.Context) string {
+	s, _ := ctx.Value(XLOG_REQ_TENANT_ID_CTX_KEY).(string)
+	return s
+}
+
 type Logger interface {
 	/*
 		Fields is a helper function to use a map or slice to set fields using type assertion.
@@ -184,6 +190,10 @@ func (zl *ZeroLogger) attachFields(ctx context.Context, e *zerolog.Event, fields
 		fields = append(fields, "reqTraceId", v)
 	}
 
+	if v, ok := ctx.Value(XLOG_REQ_TENANT_ID_CTX_KEY).(string); ok && v != "" {
+		fields = append(fields, "reqTenantId", v)
+	}
+
 	for i := 0; i < len(fields); i += 2 {
 		if isHasKV := i+1 < len(fields); !isHasKV {
 			continue
@@ -19,15 +19,22 @@ type (
 		ReqBody     []byte              `json:"reqBody"`
 		ReqSize     int64               `json:"reqSize"`
 
-		ResHeader map[string][]string `json:"resHeader"`
-		ResStatus int                 `json:"resStatus"`
-		ResSize   int64               `json:"resSize"`
-		ResBody   []byte              `json:"resBody"`
+		ResHeader  map[string][]string `json:"resHeader"`
+		ResStatus  int                 `json:"resStatus"`
+		ResSize    int64               `json:"resSize"`
+		ResBody    []byte              `json:"resBody"`
+		ResErrCode string              `json:"resErrCode"`
 
 		PanicMsg   string `json:"panicMsg"`
 		PanicStack []byte `json:"panicStack"`
 
+		Timeout time.Duration `json:"timeout"`
+
+		// SkipBodyReason tells why request and response body is not logged, i.e: "operation" and "sampling".
+		SkipBodyReason string `json:"skipBodyReason"`
+
 		IsPanic            bool `json:"isPanic"`
+		IsTimeout          bool `json:"isTimeout"`
 		IsHideRes          bool `json:"isHideRes"`
 		IsMultipart        bool `josn:"isMultipart"`
 		IsMultipartEncoded bool `josn:"isMultipartEncoded"`
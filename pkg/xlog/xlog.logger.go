@@ -13,6 +13,9 @@ type (
 
 	DebugLogger struct {
 		SingleLogger
+
+		// Redactor masks PII of logged request, response and query args, it is nil when redaction is not configured.
+		Redactor *Redactor
 	}
 )
 
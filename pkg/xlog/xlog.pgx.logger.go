@@ -23,6 +23,9 @@ func PgxQueryName(ctx context.Context, name string) context.Context {
 // PgxLogger is a custom QueryTracer implementation for pgx using zerolog.
 type PgxLogger struct {
 	Log Logger
+
+	// Redactor masks PII of query args, it is optional.
+	Redactor *Redactor
 }
 
 // TraceQueryStart logs the start of a query.
@@ -44,7 +47,7 @@ func (t *PgxLogger) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pg
 
 	fields = append(fields,
 		"querySql", data.SQL,
-		"queryArgs", data.Args,
+		"queryArgs", t.Redactor.Args(data.Args),
 		"queryStartTime", n,
 	)
 	t.Log.Debug(ctx, "start executing query", fields...)
@@ -89,7 +92,7 @@ func (t *PgxLogger) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.
 		fields = append(fields,
 			"err", data.Err,
 			"querySql", queryData.SQL,
-			"queryArgs", queryData.Args,
+			"queryArgs", t.Redactor.Args(queryData.Args),
 			"queryEndTime", queryEndTime,
 			"queryStartTime", queryStartTime,
 			"queryDuration", FormatDuration(queryDurr),
@@ -109,7 +112,7 @@ func (t *PgxLogger) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.
 
 	fields = append(fields,
 		"querySql", queryData.SQL,
-		"queryArgs", queryData.Args,
+		"queryArgs", t.Redactor.Args(queryData.Args),
 		"queryRowsAffected", data.CommandTag.RowsAffected(),
 		"queryEndTime", queryEndTime,
 		"queryStartTime", queryStartTime,
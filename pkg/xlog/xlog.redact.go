@@ -0,0 +1,370 @@
+package xlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultRedactMask replaces redacted value when the mask is not configured.
+	DefaultRedactMask = "[REDACTED]"
+
+	// descendant is JSON path token of '..', it matches the next token at any depth.
+	descendant = ".."
+)
+
+var (
+	curlHeader = regexp.MustCompile(`(-H\s+')([^:']+):\s*[^']*(')`)
+	curlData   = regexp.MustCompile(`(-d\s+')((?:[^']|'"'"')*)(')`)
+)
+
+// RedactRule is PII redaction rule of logged request and response, i.e: incoming log, resty logger and pgx query args.
+type RedactRule struct {
+	// Mask replaces the redacted value, default is DefaultRedactMask.
+	Mask string
+
+	// Headers are header names which values are redacted, it is case-insensitive, i.e: "Authorization".
+	Headers []string
+
+	// JSONPaths are paths of JSON body which values are redacted, i.e: "$.password", "$.card.number",
+	// "$.items[*].token" or "$..password" for any depth.
+	JSONPaths []string
+
+	// FormFields are form field and query param names which values are redacted, it is case-insensitive.
+	FormFields []string
+
+	// Patterns are regular expressions keyed by name, every match of any logged text is redacted, i.e: email and phone number.
+	Patterns map[string]string
+}
+
+// Redactor masks PII of logged values, nil redactor returns every value as is.
+type Redactor struct {
+	mask     string
+	headers  map[string]struct{}
+	fields   map[string]struct{}
+	paths    [][]string
+	patterns []*regexp.Regexp
+}
+
+func NewRedactor(rule RedactRule) (*Redactor, error) {
+	r := &Redactor{
+		mask:    rule.Mask,
+		headers: make(map[string]struct{}, len(rule.Headers)),
+		fields:  make(map[string]struct{}, len(rule.FormFields)),
+	}
+
+	if r.mask == "" {
+		r.mask = DefaultRedactMask
+	}
+
+	for _, h := range rule.Headers {
+		r.headers[strings.ToLower(strings.TrimSpace(h))] = struct{}{}
+	}
+
+	for _, f := range rule.FormFields {
+		r.fields[strings.ToLower(strings.TrimSpace(f))] = struct{}{}
+	}
+
+	for _, p := range rule.JSONPaths {
+		path, err := parseJSONPath(p)
+		if err != nil {
+			return nil, err
+		}
+		r.paths = append(r.paths, path)
+	}
+
+	// sorted by name, so the patterns are applied in the same order on every run
+	names := make([]string, 0, len(rule.Patterns))
+	for name := range rule.Patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		re, err := regexp.Compile(rule.Patterns[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern '%s': %w", name, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	return r, nil
+}
+
+// Enabled reports whether any rule is configured.
+func (r *Redactor) Enabled() bool {
+	return r != nil && (len(r.headers) > 0 || len(r.fields) > 0 || len(r.paths) > 0 || len(r.patterns) > 0)
+}
+
+// Text redacts every pattern match of the text.
+func (r *Redactor) Text(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+
+	for _, re := range r.patterns {
+		s = re.ReplaceAllLiteralString(s, r.mask)
+	}
+
+	return s
+}
+
+// Header returns copy of the header with redacted values.
+func (r *Redactor) Header(h map[string][]string) map[string][]string {
+	return r.values(h, r.headers)
+}
+
+// Form returns copy of the form values with redacted values.
+func (r *Redactor) Form(v map[string][]string) map[string][]string {
+	return r.values(v, r.fields)
+}
+
+func (r *Redactor) values(m map[string][]string, names map[string]struct{}) map[string][]string {
+	if !r.Enabled() || m == nil {
+		return m
+	}
+
+	out := make(map[string][]string, len(m))
+	for k, vs := range m {
+		redacted := make([]string, len(vs))
+		_, ok := names[strings.ToLower(k)]
+		for i, v := range vs {
+			if ok {
+				redacted[i] = r.mask
+				continue
+			}
+			redacted[i] = r.Text(v)
+		}
+		out[k] = redacted
+	}
+
+	return out
+}
+
+// URI redacts query params which are listed as form fields and every pattern match of the uri.
+func (r *Redactor) URI(uri string) string {
+	if !r.Enabled() || uri == "" {
+		return uri
+	}
+
+	base, query, ok := strings.Cut(uri, "?")
+	if !ok {
+		return r.Text(uri)
+	}
+
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		k, v, _ := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(k); err == nil {
+			k = name
+		}
+		if _, ok := r.fields[strings.ToLower(k)]; ok {
+			params[i] = k + "=" + r.mask
+			continue
+		}
+
+		// the patterns are matched against unescaped value, i.e: "a%40b.co" is an email
+		if value, err := url.QueryUnescape(v); err == nil {
+			if redacted := r.Text(value); redacted != value {
+				params[i] = k + "=" + redacted
+			}
+		}
+	}
+
+	return r.Text(base + "?" + strings.Join(params, "&"))
+}
+
+// JSON redacts the values of JSON paths and every pattern match of string values,
+// invalid JSON body is redacted as text.
+func (r *Redactor) JSON(b []byte) []byte {
+	if r == nil || len(b) == 0 || (len(r.paths) == 0 && len(r.patterns) == 0) {
+		return b
+	}
+
+	var (
+		v   any
+		dec = json.NewDecoder(bytes.NewReader(b))
+	)
+
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return []byte(r.Text(string(b)))
+	}
+
+	for _, path := range r.paths {
+		v = r.walk(v, path)
+	}
+	v = r.strings(v)
+
+	var (
+		buf bytes.Buffer
+		enc = json.NewEncoder(&buf)
+	)
+
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return []byte(r.Text(string(b)))
+	}
+
+	return bytes.TrimRight(buf.Bytes(), "\n")
+}
+
+// Args returns copy of the query args with redacted string values, the args are never modified.
+func (r *Redactor) Args(args []any) []any {
+	if !r.Enabled() || len(args) == 0 {
+		return args
+	}
+
+	out := make([]any, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			out[i] = r.Text(v)
+		case []byte:
+			out[i] = r.JSON(v)
+		default:
+			out[i] = arg
+		}
+	}
+
+	return out
+}
+
+// Curl redacts the headers and the JSON data of curl command.
+func (r *Redactor) Curl(cmd string) string {
+	if !r.Enabled() || cmd == "" {
+		return cmd
+	}
+
+	cmd = curlHeader.ReplaceAllStringFunc(cmd, func(s string) string {
+		m := curlHeader.FindStringSubmatch(s)
+		if _, ok := r.headers[strings.ToLower(strings.TrimSpace(m[2]))]; ok {
+			return m[1] + m[2] + ": " + r.mask + m[3]
+		}
+		return s
+	})
+
+	cmd = curlData.ReplaceAllStringFunc(cmd, func(s string) string {
+		var (
+			m    = curlData.FindStringSubmatch(s)
+			data = strings.ReplaceAll(m[2], `'"'"'`, `'`)
+		)
+		return m[1] + strings.ReplaceAll(string(r.JSON([]byte(data))), `'`, `'"'"'`) + m[3]
+	})
+
+	return r.Text(cmd)
+}
+
+func (r *Redactor) walk(v any, path []string) any {
+	if len(path) == 0 {
+		return r.mask
+	}
+
+	if path[0] == descendant {
+		v = r.walk(v, path[1:])
+		switch t := v.(type) {
+		case map[string]any:
+			for k, c := range t {
+				t[k] = r.walk(c, path)
+			}
+		case []any:
+			for i, c := range t {
+				t[i] = r.walk(c, path)
+			}
+		}
+		return v
+	}
+
+	switch t := v.(type) {
+	case map[string]any:
+		for k, c := range t {
+			if path[0] == "*" || path[0] == k {
+				t[k] = r.walk(c, path[1:])
+			}
+		}
+	case []any:
+		for i, c := range t {
+			if path[0] == "*" || path[0] == strconv.Itoa(i) {
+				t[i] = r.walk(c, path[1:])
+			}
+		}
+	}
+
+	return v
+}
+
+func (r *Redactor) strings(v any) any {
+	if len(r.patterns) == 0 {
+		return v
+	}
+
+	switch t := v.(type) {
+	case string:
+		return r.Text(t)
+	case map[string]any:
+		for k, c := range t {
+			t[k] = r.strings(c)
+		}
+	case []any:
+		for i, c := range t {
+			t[i] = r.strings(c)
+		}
+	}
+
+	return v
+}
+
+// parseJSONPath parses the subset of JSON path, i.e: "$.a.b", "$.a[0]", "$.a[*].b", "$['a'].b" and "$..b".
+func parseJSONPath(p string) ([]string, error) {
+	s, ok := strings.CutPrefix(strings.TrimSpace(p), "$")
+	if !ok || s == "" {
+		return nil, fmt.Errorf("invalid redact json path '%s': it must start with '$.'", p)
+	}
+
+	var path []string
+	for s != "" {
+		switch {
+		case strings.HasPrefix(s, descendant):
+			path = append(path, descendant)
+			s = s[len(descendant):]
+		case s[0] == '.':
+			s = s[1:]
+		case s[0] == '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid redact json path '%s': unclosed '['", p)
+			}
+			path = append(path, strings.Trim(s[1:end], `'"`))
+			s = s[end+1:]
+			continue
+		default:
+			return nil, fmt.Errorf("invalid redact json path '%s'", p)
+		}
+
+		end := strings.IndexAny(s, ".[")
+		if end < 0 {
+			end = len(s)
+		}
+		if end == 0 {
+			if len(path) > 0 && path[len(path)-1] == descendant && s != "" && s[0] == '[' {
+				continue
+			}
+			return nil, fmt.Errorf("invalid redact json path '%s': empty segment", p)
+		}
+
+		path = append(path, s[:end])
+		s = s[end:]
+	}
+
+	if len(path) == 0 || path[len(path)-1] == descendant {
+		return nil, fmt.Errorf("invalid redact json path '%s'", p)
+	}
+
+	return path, nil
+}
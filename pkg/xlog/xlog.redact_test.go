@@ -0,0 +1,258 @@
+package xlog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{path: "$.password", want: []string{"password"}},
+		{path: " $.card.number ", want: []string{"card", "number"}},
+		{path: "$.items[0]", want: []string{"items", "0"}},
+		{path: "$.items[*].token", want: []string{"items", "*", "token"}},
+		{path: "$['card'].number", want: []string{"card", "number"}},
+		{path: `$["card"]["cvv"]`, want: []string{"card", "cvv"}},
+		{path: "$..password", want: []string{descendant, "password"}},
+		{path: "$.user..token", want: []string{"user", descendant, "token"}},
+		{path: "$..[0]", want: []string{descendant, "0"}},
+		{path: "", wantErr: true},
+		{path: "$", wantErr: true},
+		{path: "password", wantErr: true},
+		{path: "$.", wantErr: true},
+		{path: "$.a..", wantErr: true},
+		{path: "$.items[0", wantErr: true},
+		{path: "$.a.", wantErr: true},
+		{path: "$a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := parseJSONPath(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseJSONPath(%q) = %v, want error", tt.path, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseJSONPath(%q) error = %v", tt.path, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseJSONPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRedactorInvalidRule(t *testing.T) {
+	tests := []struct {
+		name string
+		rule RedactRule
+	}{
+		{name: "invalid json path", rule: RedactRule{JSONPaths: []string{"password"}}},
+		{name: "invalid pattern", rule: RedactRule{Patterns: map[string]string{"broken": "("}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRedactor(tt.rule); err == nil {
+				t.Fatal("NewRedactor() error = nil, want error")
+			}
+		})
+	}
+}
+
+func newTestRedactor(t *testing.T) *Redactor {
+	t.Helper()
+
+	r, err := NewRedactor(RedactRule{
+		Mask:       "***",
+		Headers:    []string{"Authorization", " X-Api-Key "},
+		JSONPaths:  []string{"$.password", "$.card.number", "$.items[*].token", "$..secret"},
+		FormFields: []string{"access_token", "Password"},
+		Patterns:   map[string]string{"email": `[a-z]+@[a-z]+\.co`},
+	})
+	if err != nil {
+		t.Fatalf("NewRedactor() error = %v", err)
+	}
+	return r
+}
+
+func TestRedactorJSON(t *testing.T) {
+	r := newTestRedactor(t)
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "top level field",
+			in:   `{"name":"a","password":"p4ss"}`,
+			want: `{"name":"a","password":"***"}`,
+		},
+		{
+			name: "nested field",
+			in:   `{"card":{"number":"4111","holder":"a"}}`,
+			want: `{"card":{"holder":"a","number":"***"}}`,
+		},
+		{
+			name: "wildcard array",
+			in:   `{"items":[{"token":"t1","id":1},{"token":"t2","id":2}]}`,
+			want: `{"items":[{"id":1,"token":"***"},{"id":2,"token":"***"}]}`,
+		},
+		{
+			name: "descendant at any depth",
+			in:   `{"secret":1,"a":{"b":[{"secret":"x"}]}}`,
+			want: `{"a":{"b":[{"secret":"***"}]},"secret":"***"}`,
+		},
+		{
+			name: "pattern on string value",
+			in:   `{"note":"mail me at joe@mail.co"}`,
+			want: `{"note":"mail me at ***"}`,
+		},
+		{
+			name: "number is kept as is",
+			in:   `{"amount":12345678901234567890}`,
+			want: `{"amount":12345678901234567890}`,
+		},
+		{
+			name: "invalid json is redacted as text",
+			in:   `password=p4ss&email=joe@mail.co`,
+			want: `password=p4ss&email=***`,
+		},
+		{
+			name: "multiple json values are redacted as text",
+			in:   `{"password":"a"} {"password":"b"}`,
+			want: `{"password":"a"} {"password":"b"}`,
+		},
+		{
+			name: "empty body",
+			in:   ``,
+			want: ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(r.JSON([]byte(tt.in))); got != tt.want {
+				t.Fatalf("JSON(%s) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactorURI(t *testing.T) {
+	r := newTestRedactor(t)
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "without query", in: "/api/v1/user", want: "/api/v1/user"},
+		{name: "form field", in: "/events?access_token=abc&topic=jobs", want: "/events?access_token=***&topic=jobs"},
+		{name: "form field is case-insensitive", in: "/login?PASSWORD=abc", want: "/login?PASSWORD=***"},
+		{name: "escaped form field name", in: "/events?access%5Ftoken=abc", want: "/events?access_token=***"},
+		{name: "escaped pattern value", in: "/search?q=joe%40mail.co", want: "/search?q=***"},
+		{name: "pattern in path", in: "/users/joe@mail.co", want: "/users/***"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.URI(tt.in); got != tt.want {
+				t.Fatalf("URI(%s) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactorHeaderAndForm(t *testing.T) {
+	r := newTestRedactor(t)
+
+	header := map[string][]string{
+		"authorization": {"Bearer abc"},
+		"X-API-KEY":     {"k1", "k2"},
+		"From":          {"joe@mail.co"},
+		"Accept":        {"application/json"},
+	}
+	wantHeader := map[string][]string{
+		"authorization": {"***"},
+		"X-API-KEY":     {"***", "***"},
+		"From":          {"***"},
+		"Accept":        {"application/json"},
+	}
+
+	if got := r.Header(header); !reflect.DeepEqual(got, wantHeader) {
+		t.Fatalf("Header() = %v, want %v", got, wantHeader)
+	}
+	if header["authorization"][0] != "Bearer abc" {
+		t.Fatal("Header() modifies the given header, want copy")
+	}
+
+	form := map[string][]string{"password": {"p4ss"}, "name": {"joe"}}
+	wantForm := map[string][]string{"password": {"***"}, "name": {"joe"}}
+
+	if got := r.Form(form); !reflect.DeepEqual(got, wantForm) {
+		t.Fatalf("Form() = %v, want %v", got, wantForm)
+	}
+}
+
+func TestRedactorCurl(t *testing.T) {
+	r := newTestRedactor(t)
+
+	var (
+		in   = `curl -X POST -H 'Authorization: Bearer abc' -H 'Accept: */*' -d '{"password":"it'"'"'s"}' https://joe@mail.co/login`
+		want = `curl -X POST -H 'Authorization: ***' -H 'Accept: */*' -d '{"password":"***"}' https://***/login`
+	)
+
+	if got := r.Curl(in); got != want {
+		t.Fatalf("Curl() = %s, want %s", got, want)
+	}
+}
+
+func TestRedactorArgs(t *testing.T) {
+	r := newTestRedactor(t)
+
+	var (
+		args = []any{"joe@mail.co", []byte(`{"password":"p4ss"}`), 42}
+		want = []any{"***", []byte(`{"password":"***"}`), 42}
+	)
+
+	if got := r.Args(args); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Args() = %v, want %v", got, want)
+	}
+	if args[0] != "joe@mail.co" {
+		t.Fatal("Args() modifies the given args, want copy")
+	}
+}
+
+func TestRedactorDisabled(t *testing.T) {
+	empty, err := NewRedactor(RedactRule{})
+	if err != nil {
+		t.Fatalf("NewRedactor() error = %v", err)
+	}
+
+	for name, r := range map[string]*Redactor{"nil": nil, "empty": empty} {
+		t.Run(name, func(t *testing.T) {
+			if r.Enabled() {
+				t.Fatal("Enabled() = true, want false")
+			}
+			if got := r.URI("/a?access_token=b"); got != "/a?access_token=b" {
+				t.Fatalf("URI() = %s, want as is", got)
+			}
+			if got := string(r.JSON([]byte(`{"password":"a"}`))); got != `{"password":"a"}` {
+				t.Fatalf("JSON() = %s, want as is", got)
+			}
+			if got := r.Text("joe@mail.co"); got != "joe@mail.co" {
+				t.Fatalf("Text() = %s, want as is", got)
+			}
+		})
+	}
+}
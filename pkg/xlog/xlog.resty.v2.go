@@ -9,17 +9,20 @@ import (
 
 type RestyV2Logger struct {
 	Log Logger
+
+	// Redactor masks PII of the request and response, it is optional.
+	Redactor *Redactor
 }
 
-func NewRestyV2Logger(log Logger) *RestyV2Logger {
-	return &RestyV2Logger{log}
+func NewRestyV2Logger(log Logger, redactor *Redactor) *RestyV2Logger {
+	return &RestyV2Logger{log, redactor}
 }
 
 func (l *RestyV2Logger) Errorf(format string, v ...any) {
 	var (
 		ctx     = context.Background()
 		fields  = make([]any, 0)
-		logText = fmt.Sprintf(format, v...)
+		logText = l.Redactor.Text(fmt.Sprintf(format, v...))
 	)
 
 	fields = append(fields,
@@ -31,15 +34,8 @@ func (l *RestyV2Logger) Errorf(format string, v ...any) {
 
 func (l *RestyV2Logger) Warnf(format string, v ...any) {
 	var (
-		ctx       = context.Background()
-		fields    = make([]any, 0)
-		logText   = fmt.Sprintf(format, v...)
-		parsed, _ = ParseRestyLog(logText)
-	)
-
-	fields = append(fields,
-		"restyRawLog", logText,
-		"restyParsedLog", parsed,
+		ctx    = context.Background()
+		fields = l.fields(fmt.Sprintf(format, v...))
 	)
 
 	l.Log.Warn(ctx, "resty api log", fields...)
@@ -47,20 +43,35 @@ func (l *RestyV2Logger) Warnf(format string, v ...any) {
 
 func (l *RestyV2Logger) Debugf(format string, v ...any) {
 	var (
-		ctx       = context.Background()
-		fields    = make([]any, 0)
-		logText   = fmt.Sprintf(format, v...)
-		parsed, _ = ParseRestyLog(logText)
-	)
-
-	fields = append(fields,
-		"restyRawLog", logText,
-		"restyParsedLog", parsed,
+		ctx    = context.Background()
+		fields = l.fields(fmt.Sprintf(format, v...))
 	)
 
 	l.Log.Debug(ctx, "resty api log", fields...)
 }
 
+// fields returns raw and parsed log, the raw log is omitted when the redactor is enabled and the log is parsed,
+// because headers and body of the raw log can not be redacted reliably.
+func (l *RestyV2Logger) fields(logText string) []any {
+	parsed, err := ParseRestyLog(logText)
+	if !l.Redactor.Enabled() {
+		return []any{"restyRawLog", logText, "restyParsedLog", parsed}
+	}
+
+	if err != nil {
+		return []any{"restyRawLog", l.Redactor.Text(logText), "restyParsedLog", parsed}
+	}
+
+	parsed.Curl = l.Redactor.Curl(parsed.Curl)
+	parsed.Request.URL = l.Redactor.URI(parsed.Request.URL)
+	parsed.Request.Headers = l.Redactor.Header(parsed.Request.Headers)
+	parsed.Request.Body = string(l.Redactor.JSON([]byte(parsed.Request.Body)))
+	parsed.Response.Headers = l.Redactor.Header(parsed.Response.Headers)
+	parsed.Response.Body = string(l.Redactor.JSON([]byte(parsed.Response.Body)))
+
+	return []any{"restyParsedLog", parsed}
+}
+
 type (
 	HTTPLog struct {
 		Curl     string       `json:"curl"`
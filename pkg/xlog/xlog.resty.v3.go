@@ -10,16 +10,19 @@ import (
 
 type RestyV3Logger struct {
 	Log Logger
+
+	// Redactor masks PII of the request and response, it is optional.
+	Redactor *Redactor
 }
 
-func NewRestyV3Logger(log Logger) *RestyV3Logger {
-	return &RestyV3Logger{log}
+func NewRestyV3Logger(log Logger, redactor *Redactor) *RestyV3Logger {
+	return &RestyV3Logger{log, redactor}
 }
 
 func (l *RestyV3Logger) Errorf(format string, v ...any) {
 	var (
 		ctx     = context.Background()
-		logText = fmt.Sprintf(format, v...)
+		logText = l.Redactor.Text(fmt.Sprintf(format, v...))
 		fields  = []any{
 			"restyLog", logText,
 		}
@@ -40,9 +43,9 @@ func (l *RestyV3Logger) Warnf(format string, v ...any) {
 	)
 
 	if err := json.Unmarshal([]byte(text), &log); err == nil && log.Request != nil && log.Response != nil {
-		fields = append(fields, "restyParseLog", log)
+		fields = append(fields, "restyParseLog", l.redact(log))
 	} else {
-		fields = append(fields, "restyLog", text)
+		fields = append(fields, "restyLog", l.Redactor.Text(text))
 	}
 
 	l.Log.Warn(ctx, "resty api log", fields...)
@@ -60,10 +63,30 @@ func (l *RestyV3Logger) Debugf(format string, v ...any) {
 	)
 
 	if err := json.Unmarshal([]byte(text), &log); err == nil && log.Request != nil && log.Response != nil {
-		fields = append(fields, "restyParseLog", log)
+		fields = append(fields, "restyParseLog", l.redact(log))
 	} else {
-		fields = append(fields, "restyLog", text)
+		fields = append(fields, "restyLog", l.Redactor.Text(text))
 	}
 
 	l.Log.Debug(ctx, "resty api log", fields...)
 }
+
+// redact masks PII of the debug log based on the redactor rules.
+func (l *RestyV3Logger) redact(log resty.DebugLog) resty.DebugLog {
+	if !l.Redactor.Enabled() {
+		return log
+	}
+
+	req := *log.Request
+	req.URI = l.Redactor.URI(req.URI)
+	req.Header = l.Redactor.Header(req.Header)
+	req.CurlCmd = l.Redactor.Curl(req.CurlCmd)
+	req.Body = string(l.Redactor.JSON([]byte(req.Body)))
+
+	res := *log.Response
+	res.Header = l.Redactor.Header(res.Header)
+	res.Body = string(l.Redactor.JSON([]byte(res.Body)))
+
+	log.Request, log.Response = &req, &res
+	return log
+}
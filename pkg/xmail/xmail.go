@@ -2,9 +2,12 @@ package xmail
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"html/template"
+	"net"
 	"net/smtp"
+	"path/filepath"
 	"strings"
 )
 
@@ -26,6 +29,9 @@ type Email struct {
 	Subject      string
 	TemplateFile string
 	Data         any
+
+	// Funcs are template functions, i.e: localized messages of xi18n.Bundle.FuncMap.
+	Funcs map[string]any
 }
 
 // XMail is the main structure for the xmail package.
@@ -41,7 +47,7 @@ func New(config Config) XMail {
 // Send sends a single email.
 func (x XMail) Send(email Email) error {
 	// Parse the template file.
-	tmpl, err := template.ParseFiles(email.TemplateFile)
+	tmpl, err := template.New(filepath.Base(email.TemplateFile)).Funcs(email.Funcs).ParseFiles(email.TemplateFile)
 	if err != nil {
 		return fmt.Errorf("failed to parse template file: %w", err)
 	}
@@ -78,6 +84,31 @@ func (x XMail) Send(email Email) error {
 	return nil
 }
 
+// Ping dials the SMTP server and waits for its greeting, it does not authenticate nor send any email.
+func (x XMail) Ping(ctx context.Context) error {
+	var (
+		dialer net.Dialer
+		addr   = fmt.Sprintf("%s:%d", x.config.SMTPHost, x.config.SMTPPort)
+	)
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to dial smtp server: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, x.config.SMTPHost)
+	if err != nil {
+		return fmt.Errorf("failed to read smtp greeting: %w", err)
+	}
+
+	return client.Quit()
+}
+
 // Example usage:
 // func main() {
 // 	config := Config{
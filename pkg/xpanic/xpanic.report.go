@@ -0,0 +1,89 @@
+package xpanic
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// FingerprintDepth is the number of top frames which are used to group panics.
+	FingerprintDepth = 5
+)
+
+// Report is a single recovered panic, reports with the same fingerprint are grouped.
+type Report struct {
+	Fingerprint string    `json:"fingerprint"`
+	Message     string    `json:"message"`
+	TopFrames   []*Frame  `json:"topFrames"`
+	Stack       []Stack   `json:"stack"`
+	Method      string    `json:"method"`
+	Route       string    `json:"route"`
+	TraceID     string    `json:"traceId"`
+	Time        time.Time `json:"time"`
+}
+
+// Reporter persists recovered panic, i.e: grouped crash reports in database.
+type Reporter interface {
+	Report(ctx context.Context, r Report) error
+}
+
+// NewReport parses the stack of recovered value, the stack must be taken in the deferred recover function (debug.Stack).
+func NewReport(v any, stack []byte) Report {
+	var (
+		stacks     = ParseStack(bytes.NewReader(stack))
+		fp, frames = Fingerprint(stacks, FingerprintDepth)
+	)
+
+	return Report{
+		Fingerprint: fp,
+		Message:     fmt.Sprintf("%v", v),
+		TopFrames:   frames,
+		Stack:       stacks,
+		Time:        time.Now(),
+	}
+}
+
+// Fingerprint groups panics by function names of their top frames, so the same panic site has the same
+// fingerprint across requests and deploys. The frames of recover function and go runtime are skipped.
+func Fingerprint(stacks []Stack, depth int) (string, []*Frame) {
+	if len(stacks) == 0 {
+		return "", nil
+	}
+
+	var (
+		frames = stacks[0].Stack
+		start  = 0
+	)
+
+	// the panicking frames are placed after 'panic' frame, recover function and debug.Stack are before it
+	for i, f := range frames {
+		if f.Func == "panic" || f.Func == "runtime.gopanic" {
+			start = i + 1
+			break
+		}
+	}
+
+	var (
+		top   = make([]*Frame, 0, depth)
+		names = make([]string, 0, depth)
+	)
+
+	for _, f := range frames[start:] {
+		if len(top) == depth {
+			break
+		}
+		if strings.HasPrefix(f.Func, "runtime.") || strings.HasPrefix(f.Func, "runtime/debug.") {
+			continue
+		}
+		top = append(top, f)
+		names = append(names, f.Func)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(names, "\n")))
+	return hex.EncodeToString(sum[:8]), top
+}
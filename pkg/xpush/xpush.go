@@ -0,0 +1,295 @@
+package xpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/xid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+var (
+	ErrInvalidTopic = errors.New("invalid topic, it must only contain alphanumeric, '.', '_', ':' or '-' characters")
+	ErrHubClosed    = errors.New("push hub is closed")
+)
+
+var (
+	topicPattern = regexp.MustCompile(`^[a-zA-Z0-9._:-]{1,128}$`)
+)
+
+// Message is pushed into subscribers as sse event or websocket text message.
+type Message struct {
+	ID      string          `json:"id"`
+	Topic   string          `json:"topic"`
+	Event   string          `json:"event"`
+	Data    json.RawMessage `json:"data"`
+	TraceID string          `json:"traceId,omitempty"`
+	Time    time.Time       `json:"time"`
+
+	// Carrier keeps w3c trace context of the publisher, so consumer span can be linked to it.
+	Carrier map[string]string `json:"carrier,omitempty"`
+}
+
+// Context returns context which carries publisher trace id and trace context.
+func (m Message) Context(ctx context.Context) context.Context {
+	if m.TraceID != "" {
+		ctx = context.WithValue(ctx, xlog.XLOG_REQ_TRACE_ID_CTX_KEY, m.TraceID)
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(m.Carrier))
+}
+
+type Options struct {
+	// Buffer is the queue size of each subscriber, message is dropped for slow subscriber once it is full.
+	Buffer int
+
+	// Channel is redis pub/sub channel prefix, scope and topic are appended into it, i.e: 'xpush:acme/jobs.1'.
+	Channel string
+}
+
+// Hub fans out published messages into local subscribers, when redis client is given
+// messages are published through redis pub/sub, so every instance receives them.
+type Hub struct {
+	opts   Options
+	client *redis.Client
+	pubsub *redis.PubSub
+
+	mu     sync.RWMutex
+	subs   map[string]map[*Subscription]struct{}
+	closed bool
+
+	dropped atomic.Int64
+}
+
+func NewHub(opts Options, client *redis.Client) *Hub {
+	if opts.Buffer <= 0 {
+		opts.Buffer = 64
+	}
+	if opts.Channel == "" {
+		opts.Channel = "xpush:"
+	}
+
+	return &Hub{
+		opts:   opts,
+		client: client,
+		subs:   make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Start subscribes into redis pub/sub, it is no-op for local hub.
+func (h *Hub) Start(ctx context.Context) error {
+	if h.client == nil {
+		return nil
+	}
+
+	// pub/sub keeps reconnecting in background, so it must outlive the startup context
+	h.pubsub = h.client.PSubscribe(context.WithoutCancel(ctx), h.opts.Channel+"*")
+	if _, err := h.pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe push hub channel: %w", err)
+	}
+
+	go func() {
+		for m := range h.pubsub.Channel() {
+			var msg Message
+			if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+				continue
+			}
+			h.dispatch(strings.TrimPrefix(m.Channel, h.opts.Channel), msg)
+		}
+	}()
+
+	return nil
+}
+
+// Close closes every subscription and redis pub/sub.
+func (h *Hub) Close() error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.closed = true
+
+	for _, subs := range h.subs {
+		for sub := range subs {
+			sub.close()
+		}
+	}
+	h.subs = make(map[string]map[*Subscription]struct{})
+	h.mu.Unlock()
+
+	if h.pubsub != nil {
+		return h.pubsub.Close()
+	}
+	return nil
+}
+
+// Publish sends data as the given event into topic subscribers of every instance.
+// Scope isolates the topic, i.e: tenant id, the subscriber only receives message of the same scope and topic.
+func (h *Hub) Publish(ctx context.Context, scope, topic, event string, data any) (Message, error) {
+	key, err := scopedTopic(scope, topic)
+	if err != nil {
+		return Message{}, err
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to encode push message data: %w", err)
+	}
+
+	msg := Message{
+		ID:      xid.New().String(),
+		Topic:   topic,
+		Event:   event,
+		Data:    raw,
+		TraceID: xlog.GetReqTraceID(ctx),
+		Time:    time.Now(),
+		Carrier: make(map[string]string),
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(msg.Carrier))
+
+	if h.client == nil {
+		h.dispatch(key, msg)
+		return msg, nil
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to encode push message: %w", err)
+	}
+
+	if err := h.client.Publish(ctx, h.opts.Channel+key, payload).Err(); err != nil {
+		return Message{}, fmt.Errorf("failed to publish push message: %w", err)
+	}
+
+	return msg, nil
+}
+
+// Subscribe registers local subscriber of the scoped topic, the subscription must be closed once it is no longer used.
+func (h *Hub) Subscribe(scope, topic string) (*Subscription, error) {
+	key, err := scopedTopic(scope, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+
+	sub := &Subscription{
+		hub:   h,
+		key:   key,
+		topic: topic,
+		ch:    make(chan Message, h.opts.Buffer),
+		done:  make(chan struct{}),
+	}
+
+	if h.subs[key] == nil {
+		h.subs[key] = make(map[*Subscription]struct{})
+	}
+	h.subs[key][sub] = struct{}{}
+
+	return sub, nil
+}
+
+// Subscribers returns number of local subscribers.
+func (h *Hub) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	n := 0
+	for _, subs := range h.subs {
+		n += len(subs)
+	}
+	return n
+}
+
+// Dropped returns number of messages which are dropped for slow subscribers.
+func (h *Hub) Dropped() int64 {
+	return h.dropped.Load()
+}
+
+// scopedTopic returns the topic key of the scope, the scope is validated as the topic, so it can not escape its key.
+func scopedTopic(scope, topic string) (string, error) {
+	if !topicPattern.MatchString(topic) {
+		return "", ErrInvalidTopic
+	}
+	if scope == "" {
+		return topic, nil
+	}
+	if !topicPattern.MatchString(scope) {
+		return "", ErrInvalidTopic
+	}
+	return scope + "/" + topic, nil
+}
+
+func (h *Hub) dispatch(key string, msg Message) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subs[key] {
+		select {
+		case sub.ch <- msg:
+		default:
+			h.dropped.Add(1)
+		}
+	}
+}
+
+func (h *Hub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if subs, ok := h.subs[sub.key]; ok {
+		delete(subs, sub)
+		if len(subs) == 0 {
+			delete(h.subs, sub.key)
+		}
+	}
+	sub.close()
+}
+
+type Subscription struct {
+	hub   *Hub
+	key   string
+	topic string
+	ch    chan Message
+
+	once sync.Once
+	done chan struct{}
+}
+
+func (s *Subscription) Topic() string {
+	return s.topic
+}
+
+// C receives messages of the topic.
+func (s *Subscription) C() <-chan Message {
+	return s.ch
+}
+
+// Done is closed once the subscription or the hub is closed.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() { close(s.done) })
+}
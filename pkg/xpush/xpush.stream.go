@@ -0,0 +1,184 @@
+package xpush
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	ws "github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+type StreamOptions struct {
+	// Heartbeat keeps idle connection alive through proxies, it is sse comment or websocket ping.
+	Heartbeat time.Duration
+
+	// Done closes the stream, i.e: graceful shutdown draining.
+	Done <-chan struct{}
+}
+
+func (o StreamOptions) heartbeat() time.Duration {
+	if o.Heartbeat <= 0 {
+		return 15 * time.Second
+	}
+	return o.Heartbeat
+}
+
+// SSEOperationResponses documents 'text/event-stream' response.
+func SSEOperationResponses() map[string]*huma.Response {
+	return map[string]*huma.Response{
+		strconv.Itoa(http.StatusOK): {
+			Description: "Server-Sent Events stream, every event data is json encoded push message.",
+			Content: map[string]*huma.MediaType{
+				"text/event-stream": {
+					Schema:  &huma.Schema{Type: huma.TypeString},
+					Example: "id: cqbb0e2bdc0jqe6e0rlg\nevent: progress\ndata: {\"id\":\"cqbb0e2bdc0jqe6e0rlg\",\"topic\":\"jobs.1\",\"event\":\"progress\",\"data\":{\"percent\":50},\"traceId\":\"cqbb0e2bdc0jqe6e0rl0\",\"time\":\"2024-07-16T15:04:05Z\"}\n\n",
+				},
+			},
+		},
+	}
+}
+
+// SSE streams subscription messages as Server-Sent Events, the subscription is closed once the stream ends.
+//
+// Huma fiber adapter buffers the whole body before it is written, so the stream is written
+// through fasthttp body stream writer, which is started after the handler and middlewares returned.
+func SSE(sub *Subscription, opts StreamOptions) *huma.StreamResponse {
+	return &huma.StreamResponse{
+		Body: func(ctx huma.Context) {
+			ctx.SetHeader("Content-Type", "text/event-stream")
+			ctx.SetHeader("Cache-Control", "no-cache")
+			ctx.SetHeader("Connection", "keep-alive")
+			ctx.SetHeader("X-Accel-Buffering", "no")
+
+			humafiber.Unwrap(ctx).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+				defer sub.Close()
+
+				ticker := time.NewTicker(opts.heartbeat())
+				defer ticker.Stop()
+
+				// retry hint tells the client when to reconnect, i.e: after this instance is drained
+				fmt.Fprintf(w, "retry: %d\n\n", opts.heartbeat().Milliseconds())
+				if err := w.Flush(); err != nil {
+					return
+				}
+
+				for {
+					select {
+					case <-opts.Done:
+						return
+					case <-sub.Done():
+						return
+					case <-ticker.C:
+						fmt.Fprint(w, ": heartbeat\n\n")
+					case msg := <-sub.C():
+						b, err := json.Marshal(msg)
+						if err != nil {
+							continue
+						}
+						fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", msg.ID, msg.Event, b)
+					}
+
+					// flush fails once the client is disconnected
+					if err := w.Flush(); err != nil {
+						return
+					}
+				}
+			})
+		},
+	}
+}
+
+// WebSocket upgrades the request and streams subscription messages as websocket text messages,
+// subscribe is called before the upgrade, so its error (i.e: huma.StatusError) is responded as regular http error.
+func WebSocket(opts StreamOptions, subscribe func(c *fiber.Ctx) (*Subscription, error)) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !ws.IsWebSocketUpgrade(c) {
+			return fiber.ErrUpgradeRequired
+		}
+
+		sub, err := subscribe(c)
+		if se, ok := err.(huma.StatusError); ok {
+			return c.Status(se.GetStatus()).JSON(se)
+		}
+		if err != nil {
+			return err
+		}
+
+		var (
+			heartbeat = opts.heartbeat()
+			handler   = ws.New(func(conn *ws.Conn) {
+				defer sub.Close()
+				serveWebSocket(conn, sub, opts.Done, heartbeat)
+			})
+		)
+
+		if err := handler(c); err != nil {
+			sub.Close()
+			return err
+		}
+		return nil
+	}
+}
+
+func serveWebSocket(conn *ws.Conn, sub *Subscription, done <-chan struct{}, heartbeat time.Duration) {
+	var (
+		wait   = 2 * heartbeat
+		closed = make(chan struct{})
+	)
+
+	// read loop handles control frames and detects disconnected client, client messages are ignored
+	conn.SetReadDeadline(time.Now().Add(wait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wait))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-sub.Done():
+			closeWebSocket(conn, "subscription is closed")
+			return
+		case <-done:
+			closeWebSocket(conn, "server is shutting down")
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(ws.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				return
+			}
+		case msg := <-sub.C():
+			conn.SetWriteDeadline(time.Now().Add(wait))
+			if err := conn.WriteJSON(msg); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func closeWebSocket(conn *ws.Conn, reason string) {
+	_ = conn.WriteControl(ws.CloseMessage, ws.FormatCloseMessage(ws.CloseGoingAway, reason), time.Now().Add(time.Second))
+}
+
+// WebSocketRegister registers websocket routes into fiber app of every server which serves its handler group,
+// it is provided into 'global:http:websocket' fx group and it may implement xhuma.HandlerGroup and xhuma.HandlerVersion,
+// the routes of the versioned handler are registered under 'api.versions' prefix of its version.
+type WebSocketRegister interface {
+	Register(router fiber.Router)
+}
@@ -0,0 +1,42 @@
+package xredis
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsm/redislock"
+)
+
+// KeepLock refreshes the lock ttl every half of the ttl until the returned stop is called, so the lock is not expired
+// while its owner is still working. The refresh stops on the first failure, onError is called with it when it is not nil.
+// The lock must only be released after stop returns.
+func KeepLock(ctx context.Context, lock *redislock.Lock, ttl time.Duration, onError func(err error)) (stop func()) {
+	var (
+		done    = make(chan struct{})
+		stopped = make(chan struct{})
+		ticker  = time.NewTicker(ttl / 2)
+	)
+
+	go func() {
+		defer close(stopped)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := lock.Refresh(ctx, ttl, nil); err != nil {
+					if onError != nil {
+						onError(err)
+					}
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
@@ -27,6 +27,9 @@ type GeneralResponse[D any, E any] struct {
 }
 
 type ErrorModel struct {
+	// Code is stable machine-readable error code of the error catalog, see 'x-error-codes' of the openapi document.
+	Code string `json:"code,omitempty" example:"USER_NOT_FOUND" doc:"Stable machine-readable error code, the catalog is listed in 'x-error-codes' of the openapi document."`
+
 	// Title provides a short static summary of the problem. Huma will default this
 	// to the HTTP response status code text if not present.
 	Title string `json:"title,omitempty" example:"Bad Request" doc:"A short, human-readable summary of the problem type. This value should not change between occurrences of the error."`
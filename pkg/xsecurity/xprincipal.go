@@ -0,0 +1,37 @@
+package xsecurity
+
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type principalCtxKey struct{}
+
+// Principal is the owner of verified access token.
+type Principal struct {
+	Subject string
+	Claims  jwt.MapClaims
+}
+
+func NewPrincipal(claims jwt.MapClaims) Principal {
+	sub, _ := claims.GetSubject()
+	return Principal{Subject: sub, Claims: claims}
+}
+
+// Claim returns string claim by its key, empty when it is missing or not a string.
+func (p Principal) Claim(key string) string {
+	v, _ := p.Claims[key].(string)
+	return v
+}
+
+// WithPrincipal stores verified principal into context, it must only be called after the token is validated.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, p)
+}
+
+// PrincipalFrom returns verified principal from context and whether it is present.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalCtxKey{}).(Principal)
+	return p, ok
+}
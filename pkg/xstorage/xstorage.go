@@ -0,0 +1,114 @@
+package xstorage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound          = errors.New("object is not found")
+	ErrInvalidKey        = errors.New("invalid object key")
+	ErrChecksumMismatch  = errors.New("object checksum mismatch")
+	ErrInvalidChecksum   = errors.New("invalid sha256 checksum, it must be hex or base64 encoded")
+	ErrInvalidSignature  = errors.New("presigned url signature is invalid or expired")
+	ErrUnsupportedDriver = errors.New("unsupported storage driver")
+)
+
+type Object struct {
+	Key         string    `json:"key"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"contentType"`
+	Checksum    string    `json:"checksum,omitempty"` // hex encoded sha256, empty when it is unknown
+	ModifiedAt  time.Time `json:"modifiedAt"`
+}
+
+type PutOptions struct {
+	ContentType string
+
+	// Size is the object size when it is known upfront, -1 means unknown.
+	Size int64
+
+	// Checksum is the expected hex or base64 encoded sha256 of the content,
+	// the object is not stored when it does not match.
+	Checksum string
+}
+
+// Storage is object storage abstraction, keys are slash separated relative path (i.e: 'avatars/user-1.png').
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, opts PutOptions) (Object, error)
+	Get(ctx context.Context, key string) (io.ReadCloser, Object, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context, prefix string) ([]Object, error)
+	Stat(ctx context.Context, key string) (Object, error)
+	PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error)
+	PresignPut(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// CleanKey normalizes object key and rejects key which escapes the storage root.
+func CleanKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" || strings.Contains(key, "\\") || strings.HasPrefix(key, "/") {
+		return "", ErrInvalidKey
+	}
+
+	cleaned := path.Clean(key)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidKey
+	}
+
+	for _, seg := range strings.Split(cleaned, "/") {
+		if strings.HasPrefix(seg, ".") {
+			return "", ErrInvalidKey
+		}
+	}
+
+	return cleaned, nil
+}
+
+// NormalizeChecksum decodes hex or base64 encoded sha256 into hex.
+func NormalizeChecksum(checksum string) (string, error) {
+	checksum = strings.TrimSpace(checksum)
+	if checksum == "" {
+		return "", nil
+	}
+
+	if b, err := hex.DecodeString(checksum); err == nil && len(b) == sha256.Size {
+		return strings.ToLower(checksum), nil
+	}
+
+	if b, err := base64.StdEncoding.DecodeString(checksum); err == nil && len(b) == sha256.Size {
+		return hex.EncodeToString(b), nil
+	}
+
+	return "", ErrInvalidChecksum
+}
+
+// hashReader computes sha256 and size of the content while it is read.
+type hashReader struct {
+	r    io.Reader
+	h    hash.Hash
+	size int64
+}
+
+func newHashReader(r io.Reader) *hashReader {
+	h := sha256.New()
+	return &hashReader{r: io.TeeReader(r, h), h: h}
+}
+
+func (r *hashReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *hashReader) Checksum() string {
+	return hex.EncodeToString(r.h.Sum(nil))
+}
@@ -0,0 +1,313 @@
+package xstorage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// localMetaDir keeps object metadata (content type and checksum) as json sidecar files.
+	localMetaDir = ".meta"
+)
+
+type LocalConfig struct {
+	Root string
+
+	// BaseURL is the endpoint which serves presigned url, the signed key is appended into it.
+	BaseURL string
+
+	// Secret signs presigned url with HMAC-SHA256.
+	Secret string
+}
+
+// LocalStorage stores objects on local disk, presigned url is signed by HMAC
+// and must be verified by the endpoint behind 'BaseURL' through Verify.
+type LocalStorage struct {
+	cfg LocalConfig
+}
+
+func NewLocalStorage(cfg LocalConfig) (*LocalStorage, error) {
+	if cfg.Root == "" {
+		return nil, errors.New("local storage root is required")
+	}
+	if cfg.Secret == "" {
+		return nil, errors.New("local storage secret is required to sign presigned url")
+	}
+
+	if err := os.MkdirAll(filepath.Join(cfg.Root, localMetaDir), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage root: %w", err)
+	}
+
+	return &LocalStorage{cfg: cfg}, nil
+}
+
+type localMeta struct {
+	ContentType string `json:"contentType"`
+	Checksum    string `json:"checksum"`
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, opts PutOptions) (Object, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return Object{}, err
+	}
+
+	expected, err := NormalizeChecksum(opts.Checksum)
+	if err != nil {
+		return Object{}, err
+	}
+
+	dst := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return Object{}, fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	// write into temporary file first, so partial or mismatched upload never replaces the object
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".upload-*")
+	if err != nil {
+		return Object{}, fmt.Errorf("failed to create temporary object file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hr := newHashReader(r)
+	if _, err := io.Copy(tmp, &ctxReader{ctx: ctx, r: hr}); err != nil {
+		tmp.Close()
+		return Object{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return Object{}, fmt.Errorf("failed to write object file: %w", err)
+	}
+
+	checksum := hr.Checksum()
+	if expected != "" && expected != checksum {
+		return Object{}, ErrChecksumMismatch
+	}
+
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return Object{}, fmt.Errorf("failed to move object file: %w", err)
+	}
+
+	meta := localMeta{ContentType: opts.ContentType, Checksum: checksum}
+	if err := s.writeMeta(key, meta); err != nil {
+		return Object{}, err
+	}
+
+	return s.Stat(ctx, key)
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	obj, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, Object{}, err
+	}
+
+	f, err := os.Open(s.path(obj.Key))
+	if err != nil {
+		return nil, Object{}, s.mapErr(err)
+	}
+
+	return f, obj, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	key, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(s.path(key)); err != nil {
+		return s.mapErr(err)
+	}
+	_ = os.Remove(s.metaPath(key))
+
+	return nil
+}
+
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]Object, error) {
+	objects := make([]Object, 0)
+	err := filepath.WalkDir(s.cfg.Root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// skip metadata and temporary upload files
+		if strings.HasPrefix(d.Name(), ".") && p != s.cfg.Root {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.cfg.Root, p)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		obj, err := s.Stat(ctx, key)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, obj)
+
+		return nil
+	})
+
+	return objects, err
+}
+
+func (s *LocalStorage) Stat(ctx context.Context, key string) (Object, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return Object{}, err
+	}
+
+	info, err := os.Stat(s.path(key))
+	if err != nil {
+		return Object{}, s.mapErr(err)
+	}
+	if info.IsDir() {
+		return Object{}, ErrNotFound
+	}
+
+	meta := s.readMeta(key)
+	if meta.ContentType == "" {
+		meta.ContentType = mime.TypeByExtension(filepath.Ext(key))
+	}
+
+	return Object{
+		Key:         key,
+		Size:        info.Size(),
+		ContentType: meta.ContentType,
+		Checksum:    meta.Checksum,
+		ModifiedAt:  info.ModTime(),
+	}, nil
+}
+
+func (s *LocalStorage) PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.presign(http.MethodGet, key, expiry)
+}
+
+func (s *LocalStorage) PresignPut(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.presign(http.MethodPut, key, expiry)
+}
+
+// Verify validates presigned url parameters which are generated by PresignGet or PresignPut.
+func (s *LocalStorage) Verify(method, key string, expires int64, signature string) error {
+	key, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	if time.Now().Unix() > expires {
+		return ErrInvalidSignature
+	}
+
+	expected := s.sign(method, key, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) presign(method, key string, expiry time.Duration) (string, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		expires = time.Now().Add(expiry).Unix()
+		query   = url.Values{
+			"expires":   []string{strconv.FormatInt(expires, 10)},
+			"signature": []string{s.sign(method, key, expires)},
+		}
+	)
+
+	return fmt.Sprintf("%s/%s?%s", strings.TrimSuffix(s.cfg.BaseURL, "/"), url.PathEscape(key), query.Encode()), nil
+}
+
+func (s *LocalStorage) sign(method, key string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.Secret))
+	fmt.Fprintf(mac, "%s\n%s\n%d", method, key, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStorage) path(key string) string {
+	return filepath.Join(s.cfg.Root, filepath.FromSlash(key))
+}
+
+func (s *LocalStorage) metaPath(key string) string {
+	return filepath.Join(s.cfg.Root, localMetaDir, filepath.FromSlash(key)+".json")
+}
+
+func (s *LocalStorage) readMeta(key string) localMeta {
+	var meta localMeta
+	if b, err := os.ReadFile(s.metaPath(key)); err == nil {
+		_ = json.Unmarshal(b, &meta)
+	}
+	return meta
+}
+
+func (s *LocalStorage) writeMeta(key string, meta localMeta) error {
+	p := s.metaPath(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("failed to create object metadata directory: %w", err)
+	}
+
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(p, b, 0o644); err != nil {
+		return fmt.Errorf("failed to write object metadata: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) mapErr(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// ctxReader stops reading once the context is done, so a canceled upload does not keep writing into disk.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,211 @@
+package xstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	// s3MetaChecksum is stored as 'X-Amz-Meta-Checksum-Sha256' user metadata.
+	s3MetaChecksum = "Checksum-Sha256"
+)
+
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+
+	// PathStyle is required by most S3-compatible stand-in (i.e: minio, localstack).
+	PathStyle bool
+}
+
+// S3Storage stores objects in S3-compatible object storage.
+type S3Storage struct {
+	cfg    S3Config
+	client *minio.Client
+}
+
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	lookup := minio.BucketLookupDNS
+	if cfg.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure:       cfg.UseSSL,
+		Region:       cfg.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client: %w", err)
+	}
+
+	return &S3Storage{cfg: cfg, client: client}, nil
+}
+
+// Ping checks the bucket is reachable and exists.
+func (s *S3Storage) Ping(ctx context.Context) error {
+	ok, err := s.client.BucketExists(ctx, s.cfg.Bucket)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("s3 bucket '%s' does not exist", s.cfg.Bucket)
+	}
+	return nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, opts PutOptions) (Object, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return Object{}, err
+	}
+
+	expected, err := NormalizeChecksum(opts.Checksum)
+	if err != nil {
+		return Object{}, err
+	}
+
+	var (
+		hr      = newHashReader(r)
+		putOpts = minio.PutObjectOptions{ContentType: opts.ContentType}
+		size    = opts.Size
+	)
+
+	if expected != "" {
+		putOpts.UserMetadata = map[string]string{s3MetaChecksum: expected}
+	}
+	if size == 0 {
+		size = -1
+	}
+
+	if _, err := s.client.PutObject(ctx, s.cfg.Bucket, key, hr, size, putOpts); err != nil {
+		return Object{}, fmt.Errorf("failed to put s3 object: %w", err)
+	}
+
+	// the content is only known after it is uploaded, so mismatched object is removed afterward
+	if checksum := hr.Checksum(); expected != "" && expected != checksum {
+		if err := s.client.RemoveObject(context.WithoutCancel(ctx), s.cfg.Bucket, key, minio.RemoveObjectOptions{}); err != nil {
+			return Object{}, errors.Join(ErrChecksumMismatch, err)
+		}
+		return Object{}, ErrChecksumMismatch
+	}
+
+	obj, err := s.Stat(ctx, key)
+	if err != nil {
+		return Object{}, err
+	}
+	obj.Checksum = hr.Checksum()
+
+	return obj, nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	obj, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, Object{}, err
+	}
+
+	r, err := s.client.GetObject(ctx, s.cfg.Bucket, obj.Key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, Object{}, s.mapErr(err)
+	}
+
+	return r, obj, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	key, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.Stat(ctx, key); err != nil {
+		return err
+	}
+
+	return s.mapErr(s.client.RemoveObject(ctx, s.cfg.Bucket, key, minio.RemoveObjectOptions{}))
+}
+
+func (s *S3Storage) List(ctx context.Context, prefix string) ([]Object, error) {
+	objects := make([]Object, 0)
+	for info := range s.client.ListObjects(ctx, s.cfg.Bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if info.Err != nil {
+			return nil, s.mapErr(info.Err)
+		}
+		objects = append(objects, s.object(info))
+	}
+	return objects, nil
+}
+
+func (s *S3Storage) Stat(ctx context.Context, key string) (Object, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return Object{}, err
+	}
+
+	info, err := s.client.StatObject(ctx, s.cfg.Bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return Object{}, s.mapErr(err)
+	}
+
+	return s.object(info), nil
+}
+
+func (s *S3Storage) PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := s.client.PresignedGetObject(ctx, s.cfg.Bucket, key, expiry, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign s3 get object: %w", err)
+	}
+	return u.String(), nil
+}
+
+func (s *S3Storage) PresignPut(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := s.client.PresignedPutObject(ctx, s.cfg.Bucket, key, expiry)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign s3 put object: %w", err)
+	}
+	return u.String(), nil
+}
+
+func (s *S3Storage) object(info minio.ObjectInfo) Object {
+	return Object{
+		Key:         info.Key,
+		Size:        info.Size,
+		ContentType: info.ContentType,
+		Checksum:    info.UserMetadata[s3MetaChecksum],
+		ModifiedAt:  info.LastModified,
+	}
+}
+
+func (s *S3Storage) mapErr(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if resp := minio.ToErrorResponse(err); resp.StatusCode == http.StatusNotFound || resp.Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
+}
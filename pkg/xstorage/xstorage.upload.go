@@ -0,0 +1,127 @@
+package xstorage
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrTooLarge            = errors.New("upload content is too large")
+	ErrContentTypeMismatch = errors.New("declared content type does not match the content")
+	ErrContentTypeDenied   = errors.New("upload content type is not allowed")
+)
+
+// sniffLen is the number of bytes used by http.DetectContentType.
+const sniffLen = 512
+
+type UploadPolicy struct {
+	// MaxSize is the maximum upload size in bytes, zero means unlimited.
+	MaxSize int64
+
+	// AllowedTypes supports wildcard subtype (i.e: 'image/*'), empty means every type is allowed.
+	AllowedTypes []string
+}
+
+// UploadReader enforces upload policy while the content is streamed into storage,
+// content type is sniffed from the first bytes instead of trusting the client.
+type UploadReader struct {
+	r           io.Reader
+	read        int64
+	max         int64
+	ContentType string
+}
+
+func NewUploadReader(r io.Reader, declared string, policy UploadPolicy) (*UploadReader, error) {
+	br := bufio.NewReaderSize(r, sniffLen)
+
+	head, err := br.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, fmt.Errorf("failed to read upload content: %w", err)
+	}
+
+	sniffed := mediaType(http.DetectContentType(head))
+	if declared = mediaType(declared); declared != "" && !compatible(declared, sniffed) {
+		return nil, fmt.Errorf("%w: declared '%s', detected '%s'", ErrContentTypeMismatch, declared, sniffed)
+	}
+
+	// generic sniff result is refined by declared type, i.e: 'text/plain' into 'text/csv'
+	contentType := sniffed
+	if declared != "" && declared != "application/octet-stream" && isGeneric(sniffed) {
+		contentType = declared
+	}
+
+	if !allowed(contentType, policy.AllowedTypes) {
+		return nil, fmt.Errorf("%w: '%s'", ErrContentTypeDenied, contentType)
+	}
+
+	return &UploadReader{r: br, max: policy.MaxSize, ContentType: contentType}, nil
+}
+
+func (u *UploadReader) Read(p []byte) (int, error) {
+	n, err := u.r.Read(p)
+	u.read += int64(n)
+	if u.max > 0 && u.read > u.max {
+		return n, fmt.Errorf("%w: limit is %d bytes", ErrTooLarge, u.max)
+	}
+	return n, err
+}
+
+func (u *UploadReader) Size() int64 {
+	return u.read
+}
+
+func mediaType(v string) string {
+	if v == "" {
+		return ""
+	}
+	mt, _, err := mime.ParseMediaType(v)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(v))
+	}
+	return mt
+}
+
+func isGeneric(mt string) bool {
+	return mt == "application/octet-stream" || mt == "text/plain"
+}
+
+// compatible tells whether declared type is acceptable for the sniffed content,
+// the sniffer only knows a limited set of signatures, so generic result accepts any declared type
+// except the declared type is a well known signature that should have been detected.
+func compatible(declared, sniffed string) bool {
+	if declared == sniffed || declared == "application/octet-stream" {
+		return true
+	}
+
+	if isGeneric(sniffed) {
+		return !strings.HasPrefix(declared, "image/") && !strings.HasPrefix(declared, "video/") &&
+			!strings.HasPrefix(declared, "audio/") && declared != "application/pdf" && declared != "application/zip"
+	}
+
+	// i.e: 'application/json' is sniffed as 'text/plain', '*.docx' is sniffed as 'application/zip'
+	return strings.HasPrefix(sniffed, "text/") && strings.HasPrefix(declared, "text/") ||
+		sniffed == "application/zip" && strings.HasPrefix(declared, "application/vnd.openxmlformats")
+}
+
+func allowed(mt string, types []string) bool {
+	if len(types) == 0 {
+		return true
+	}
+
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == mt || t == "*/*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(t, "/*"); ok && strings.HasPrefix(mt, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,33 @@
+package xtenant
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+var (
+	ErrTenantNotFound = errors.New("tenant is not found in context")
+)
+
+// WithTenant stores tenant id into context using the same key that
+// logger and tracer read from, see: xlog.XLOG_REQ_TENANT_ID_CTX_KEY.
+func WithTenant(ctx context.Context, tenantId string) context.Context {
+	return context.WithValue(ctx, xlog.XLOG_REQ_TENANT_ID_CTX_KEY, tenantId)
+}
+
+// FromContext returns tenant id from context and whether it is present.
+func FromContext(ctx context.Context) (string, bool) {
+	id := xlog.GetReqTenantID(ctx)
+	return id, id != ""
+}
+
+// Require returns tenant id from context or ErrTenantNotFound.
+func Require(ctx context.Context) (string, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return "", ErrTenantNotFound
+	}
+	return id, nil
+}
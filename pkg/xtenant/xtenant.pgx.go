@@ -0,0 +1,84 @@
+package xtenant
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DBTX is the same contract as generated sqlc DBTX, so Guard can wrap
+// *pgxpool.Pool, pgx.Tx or any other sqlc compatible executor.
+type DBTX interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+}
+
+// Guard only refuses any query which is executed without tenant inside the context, it does not scope the query itself.
+// The rows are only scoped by the tenant predicate of the query, or by postgres row level security
+// with the tenant which is bound on connection acquire, see PgxBeforeAcquire.
+type Guard struct {
+	db DBTX
+}
+
+func NewGuard(db DBTX) *Guard {
+	return &Guard{db}
+}
+
+func (g *Guard) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	if _, err := Require(ctx); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return g.db.Exec(ctx, sql, args...)
+}
+
+func (g *Guard) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	if _, err := Require(ctx); err != nil {
+		return nil, err
+	}
+	return g.db.Query(ctx, sql, args...)
+}
+
+func (g *Guard) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	if _, err := Require(ctx); err != nil {
+		return errRow{err}
+	}
+	return g.db.QueryRow(ctx, sql, args...)
+}
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(dest ...any) error {
+	return r.err
+}
+
+// # Postgres Row Level Security
+//
+// PgxBeforeAcquire and PgxAfterRelease are meant to be attached into pgxpool.Config,
+// so every acquired connection is bound to the tenant from the acquiring context:
+//
+//	poolCfg.BeforeAcquire = xtenant.PgxBeforeAcquire
+//	poolCfg.AfterRelease  = xtenant.PgxAfterRelease
+//
+// Policy example:
+//
+//	ALTER TABLE example_users ENABLE ROW LEVEL SECURITY;
+//	CREATE POLICY tenant_isolation ON example_users
+//	  USING (tenant_id = current_setting('app.tenant_id', true));
+func PgxBeforeAcquire(ctx context.Context, conn *pgx.Conn) bool {
+	id, _ := FromContext(ctx)
+	if _, err := conn.Exec(ctx, "SELECT set_config('app.tenant_id', $1, false)", id); err != nil {
+		return false
+	}
+	return true
+}
+
+func PgxAfterRelease(conn *pgx.Conn) bool {
+	if _, err := conn.Exec(context.Background(), "RESET app.tenant_id"); err != nil {
+		return false
+	}
+	return true
+}
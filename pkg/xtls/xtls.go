@@ -0,0 +1,273 @@
+package xtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownTLSVersion  = errors.New("unknown tls version")
+	ErrUnknownCipherSuite = errors.New("unknown tls cipher suite")
+	ErrUnknownClientAuth  = errors.New("unknown tls client auth type")
+	ErrInvalidClientCA    = errors.New("no valid certificate is found in client ca file")
+)
+
+type Config struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	ClientAuth   string
+	MinVersion   string
+	CipherSuites []string
+
+	// ReloadInterval is the duration between certificate and client ca file checks,
+	// the check is done by background goroutine of CertReloader.Start.
+	ReloadInterval time.Duration
+}
+
+// NewConfig builds *tls.Config with certificate and client ca hot-reload support,
+// the returned reloader must be started to watch the files.
+func NewConfig(cfg Config) (*tls.Config, *CertReloader, error) {
+	minVersion, err := ParseVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	suites, err := ParseCipherSuites(cfg.CipherSuites)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientAuth, err := ParseClientAuth(cfg.ClientAuth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reloader, err := NewCertReloader(cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile, cfg.ReloadInterval)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion:     minVersion,
+		CipherSuites:   suites,
+		ClientAuth:     clientAuth,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	if cfg.ClientCAFile != "" {
+		// mTLS is expected when client ca is set and client auth is not configured
+		if cfg.ClientAuth == "" {
+			tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+
+		// client ca pool is read on every handshake, so the reloaded pool is used without restarting the server
+		base := tlsCfg.Clone()
+		tlsCfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c := base.Clone()
+			c.ClientCAs = reloader.ClientCAs()
+			return c, nil
+		}
+	}
+
+	return tlsCfg, reloader, nil
+}
+
+func ParseVersion(v string) (uint16, error) {
+	switch v {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.0":
+		return tls.VersionTLS10, nil
+	}
+	return 0, fmt.Errorf("%w: '%s'", ErrUnknownTLSVersion, v)
+}
+
+// ParseCipherSuites maps IANA cipher suite names (i.e: 'TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256')
+// into its id, empty names means go default cipher suites.
+// Note: cipher suites are not configurable for TLS 1.3.
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	known := make(map[string]uint16)
+	for _, s := range tls.CipherSuites() {
+		known[s.Name] = s.ID
+	}
+	for _, s := range tls.InsecureCipherSuites() {
+		known[s.Name] = s.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := known[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: '%s'", ErrUnknownCipherSuite, name)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func ParseClientAuth(v string) (tls.ClientAuthType, error) {
+	switch v {
+	case "", "none":
+		return tls.NoClientCert, nil
+	case "request":
+		return tls.RequestClientCert, nil
+	case "require":
+		return tls.RequireAnyClientCert, nil
+	case "verify.if.given":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require.and.verify":
+		return tls.RequireAndVerifyClientCert, nil
+	}
+	return tls.NoClientCert, fmt.Errorf("%w: '%s'", ErrUnknownClientAuth, v)
+}
+
+// CertReloader reloads key pair and client ca when any of their files is changed on disk,
+// the files are checked by background goroutine, so tls handshake never waits for file I/O.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	interval time.Duration
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+	modTime time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewCertReloader(certFile, keyFile, caFile string, interval time.Duration) (*CertReloader, error) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	r := &CertReloader{certFile: certFile, keyFile: keyFile, caFile: caFile, interval: interval}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// ClientCAs returns the current client ca pool, it is nil when client ca file is not set.
+func (r *CertReloader) ClientCAs() *x509.CertPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.pool
+}
+
+// Start watches the files every interval until Stop is called, the error is passed to 'onError'
+// and the previous key pair and client ca are kept serving.
+func (r *CertReloader) Start(onError func(error)) {
+	r.stop, r.done = make(chan struct{}), make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				if err := r.Reload(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops the watcher and waits for it to exit.
+func (r *CertReloader) Stop() {
+	if r.stop == nil {
+		return
+	}
+	close(r.stop)
+	<-r.done
+	r.stop = nil
+}
+
+// Reload loads the files when any of them is changed, the files are read outside of the lock,
+// and the key pair and client ca are swapped together only when both are valid.
+func (r *CertReloader) Reload() error {
+	modTime, err := r.lastModTime()
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	loaded := r.cert != nil && !modTime.After(r.modTime)
+	r.mu.RUnlock()
+
+	if loaded {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load tls key pair: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("failed to read tls client ca file: %w", err)
+		}
+
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return ErrInvalidClientCA
+		}
+	}
+
+	r.mu.Lock()
+	r.cert, r.pool, r.modTime = &cert, pool, modTime
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *CertReloader) lastModTime() (time.Time, error) {
+	var last time.Time
+	for _, f := range []string{r.certFile, r.keyFile, r.caFile} {
+		if f == "" {
+			continue
+		}
+		info, err := os.Stat(f)
+		if err != nil {
+			return last, fmt.Errorf("failed to stat tls file: %w", err)
+		}
+		if info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+	return last, nil
+}
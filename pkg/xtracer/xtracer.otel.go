@@ -36,6 +36,10 @@ func Start(tracer trace.Tracer, ctx context.Context, span string, opts ...trace.
 		opts = append(opts, trace.WithAttributes(attribute.String("reqTraceId", reqTraceId)))
 	}
 
+	if reqTenantId, ok := ctx.Value(xlog.XLOG_REQ_TENANT_ID_CTX_KEY).(string); ok && reqTenantId != "" {
+		opts = append(opts, trace.WithAttributes(attribute.String("reqTenantId", reqTenantId)))
+	}
+
 	opts = append(opts, trace.WithTimestamp(time.Now()))
 
 	return tracer.Start(ctx, span, opts...)
@@ -48,6 +52,9 @@ type Config struct {
 	Metric bool
 	Logs   bool
 
+	// Prometheus enables pull based metric reader, it works without otel collector.
+	Prometheus bool
+
 	ModuleName    string
 	ServerName    string
 	ServerAddress string
@@ -82,7 +89,7 @@ func NewGrpcClient(cfg Config) (*GrpcClient, error) {
 }
 
 func NewResource(ctx context.Context, cfg Config) (*sdkresource.Resource, error) {
-	if !cfg.Tracer && !cfg.Metric {
+	if !cfg.Tracer && !cfg.Metric && !cfg.Prometheus {
 		return nil, nil
 	}
 
@@ -139,7 +146,9 @@ func NewOtelTracer(ctx context.Context, cfg Config, res *sdkresource.Resource, c
 	return tracer, tracerShutdownFn, nil
 }
 
-func NewOtelMeter(ctx context.Context, cfg Config, res *sdkresource.Resource, conn *grpc.ClientConn) (metric.Meter, ShutdownFn, error) {
+// NewOtelMeter creates meter provider with otlp reader when metric is enabled and the given extra readers
+// (i.e: prometheus reader), noop provider is used when there is no reader at all.
+func NewOtelMeter(ctx context.Context, cfg Config, res *sdkresource.Resource, conn *grpc.ClientConn, readers ...sdkmetric.Reader) (metric.Meter, ShutdownFn, error) {
 	var (
 		meterShutdownFn ShutdownFn
 		meterProvider   metric.MeterProvider
@@ -150,12 +159,18 @@ func NewOtelMeter(ctx context.Context, cfg Config, res *sdkresource.Resource, co
 		if err != nil {
 			return nil, nil, fmt.Errorf("'failed to create otel metrics exporter': %w", err)
 		}
+		readers = append(readers, sdkmetric.NewPeriodicReader(metricExporter))
+	}
 
-		meterProvider = sdkmetric.NewMeterProvider(
-			sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
-			sdkmetric.WithResource(res),
-		)
-		meterShutdownFn = metricExporter.Shutdown
+	if len(readers) > 0 {
+		opts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+		for _, reader := range readers {
+			opts = append(opts, sdkmetric.WithReader(reader))
+		}
+
+		provider := sdkmetric.NewMeterProvider(opts...)
+		meterProvider = provider
+		meterShutdownFn = provider.Shutdown
 	} else {
 		meterProvider = metricnoop.NewMeterProvider()
 		meterShutdownFn = func(ctx context.Context) error { return nil }
@@ -0,0 +1,49 @@
+package xtracer
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/otel/metric"
+
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// Prometheus holds dedicated registry for otel prometheus exporter,
+// so the scrape output only contains otel instruments. The fields are nil when it is disabled.
+type Prometheus struct {
+	Registry *prometheus.Registry
+	Reader   sdkmetric.Reader
+}
+
+func NewPrometheus(cfg Config) (*Prometheus, error) {
+	if !cfg.Prometheus {
+		return &Prometheus{}, nil
+	}
+
+	registry := prometheus.NewRegistry()
+	reader, err := otelprom.New(otelprom.WithRegisterer(registry))
+	if err != nil {
+		return nil, fmt.Errorf("'failed to create otel prometheus exporter': %w", err)
+	}
+
+	return &Prometheus{Registry: registry, Reader: reader}, nil
+}
+
+func (p *Prometheus) Enabled() bool {
+	return p != nil && p.Registry != nil
+}
+
+// Handler serves prometheus text exposition format.
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.Registry, promhttp.HandlerOpts{})
+}
+
+// StartRuntimeMetric records go runtime metrics (i.e: memory, gc, goroutines) into the given meter provider.
+func StartRuntimeMetric(provider metric.MeterProvider) error {
+	return runtime.Start(runtime.WithMeterProvider(provider))
+}
@@ -0,0 +1,64 @@
+package xtracer
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/rs/xid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xlog"
+)
+
+const (
+	// HeaderRequestID carries request trace id across services, it is echoed in every response.
+	HeaderRequestID = "X-Request-ID"
+
+	// HeaderTraceParent is W3C trace context header.
+	HeaderTraceParent = "traceparent"
+)
+
+// requestID limits incoming request id, so arbitrary value is not written into logs and response headers.
+var requestID = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+
+// RequestID resolves request trace id from the carrier, it is the incoming request id header when it is valid,
+// otherwise trace id of the incoming traceparent, otherwise a new xid.
+func RequestID(carrier propagation.TextMapCarrier, incoming bool) string {
+	if incoming {
+		if id := strings.TrimSpace(carrier.Get(HeaderRequestID)); requestID.MatchString(id) {
+			return id
+		}
+
+		sc := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), carrier))
+		if sc.IsValid() {
+			return sc.TraceID().String()
+		}
+	}
+
+	return xid.New().String()
+}
+
+// Extract links the context into the incoming traceparent, it is kept as is when the span is already started
+// from the traceparent, i.e: by otel middleware.
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, carrier)
+}
+
+// Inject writes traceparent and request trace id of the context into outgoing carrier,
+// request id which is already set by the caller is kept.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if carrier.Get(HeaderTraceParent) == "" {
+		propagation.TraceContext{}.Inject(ctx, carrier)
+	}
+
+	if id := xlog.GetReqTraceID(ctx); id != "" && carrier.Get(HeaderRequestID) == "" {
+		carrier.Set(HeaderRequestID, id)
+	}
+}
@@ -0,0 +1,49 @@
+package xvalidate
+
+import (
+	"context"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/Mind2Screen-Dev-Team/thousand-sunny/pkg/xi18n"
+)
+
+const (
+	// I18nKeyPrefix is the prefix of ozzo-validation error code in i18n catalog, i.e: 'ozzo.validation_required'.
+	I18nKeyPrefix = "ozzo."
+)
+
+// Localize translates ozzo-validation error messages into the locale of the context (xi18n.WithLocale)
+// using the default bundle, the localized message may use the error params, i.e: "{{.min}}".
+func Localize(ctx context.Context, err error) error {
+	var (
+		b      = xi18n.Default()
+		locale = xi18n.LocaleFrom(ctx)
+	)
+
+	if err == nil || b == nil || locale == "" {
+		return err
+	}
+
+	return localize(b, locale, err)
+}
+
+// WrapperValidationLocalized is WrapperValidation with localized messages.
+func WrapperValidationLocalized(ctx context.Context, err error) error {
+	return WrapperValidation(Localize(ctx, err))
+}
+
+func localize(b *xi18n.Bundle, locale string, err error) error {
+	switch e := err.(type) {
+	case validation.Errors:
+		for field, fe := range e {
+			e[field] = localize(b, locale, fe)
+		}
+		return e
+	case validation.Error:
+		if msg, ok := b.Message(locale, I18nKeyPrefix+e.Code()); ok {
+			return e.SetMessage(msg)
+		}
+	}
+	return err
+}